@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,12 +9,21 @@ import (
 	"tft-sim/models"
 	"tft-sim/output"
 	"tft-sim/sim"
+	"tft-sim/sim/augments"
 	"tft-sim/sim/items"
+	"tft-sim/sim/mechanics"
+	"tft-sim/sim/traits"
 	"tft-sim/sim/units"
+	"time"
 )
 
-// runSimulation runs a simulation with a specific build and returns the results
-func runSimulation(buildName string, itemNames []string) (sim.SimulationResult, error) {
+// runSimulation runs a simulation with a specific build and returns the results.
+// traitCounts are the active trait counts for the scenario; nil computes them
+// from a board containing only the simulated unit. startDelay holds the unit
+// back before its first action, see sim.SimulationConfig.StartDelay, and
+// preCast lets it cast with full mana during the delay.
+// mechanicNames are the set mechanics in play, see sim.SetMechanic.
+func runSimulation(buildName string, itemNames []string, augmentNames []string, traitCounts map[string]int, startDelay time.Duration, preCast bool, mechanicNames []string) (sim.SimulationResult, error) {
 	// Get Yunara unit from registry (1-star)
 	unit, exists := units.Get("Yunara", 2)
 	if !exists {
@@ -23,12 +33,33 @@ func runSimulation(buildName string, itemNames []string) (sim.SimulationResult,
 	// Add items
 	for _, itemName := range itemNames {
 		if item, exists := items.Get(itemName); exists {
-			unit.AddItem(item)
+			if err := unit.AddItem(item); err != nil {
+				return sim.SimulationResult{}, fmt.Errorf("invalid item build: %w", err)
+			}
 		} else {
 			return sim.SimulationResult{}, fmt.Errorf("item %s not found in registry", itemName)
 		}
 	}
 
+	// Add augments
+	if err := augments.Apply(unit, augmentNames); err != nil {
+		return sim.SimulationResult{}, err
+	}
+
+	// Activate traits
+	if traitCounts == nil {
+		traitCounts = traits.CountTraits([]*models.Unit{unit})
+	}
+	if err := traits.Apply(unit, traitCounts); err != nil {
+		return sim.SimulationResult{}, err
+	}
+
+	// Create the set mechanics, fresh for this combat
+	setMechanics, err := mechanics.New(mechanicNames)
+	if err != nil {
+		return sim.SimulationResult{}, err
+	}
+
 	// Create targets
 	targets := []*models.Target{
 		models.NewTarget("Frontline Tank", 50000, 100, 50),
@@ -36,11 +67,20 @@ func runSimulation(buildName string, itemNames []string) (sim.SimulationResult,
 
 	// Create and run simulation
 	simulator := sim.NewSimulator(unit, targets)
+	simulator.Config.Timeline = sim.DefaultTimelineConfig()
+	simulator.Config.StartDelay = startDelay
+	simulator.Config.PreCast = preCast
+	simulator.Config.Mechanics = setMechanics
 	results := simulator.Run()
 
 	// Print build summary
 	fmt.Printf("\n=== Build: %s ===\n", buildName)
 	fmt.Printf("Items: %v\n", itemNames)
+	fmt.Printf("Augments: %v\n", results.Augments)
+	fmt.Printf("Traits: %v\n", results.ActiveTraits)
+	if len(results.Mechanics) > 0 {
+		fmt.Printf("Set Mechanics: %v\n", results.Mechanics)
+	}
 	fmt.Printf("Total Damage: %.1f\n", results.TotalDamage)
 	fmt.Printf("DPS: %.1f\n", results.DPS)
 	fmt.Printf("Simulation Duration: %.2fs\n", simulator.Time.Seconds())
@@ -48,39 +88,107 @@ func runSimulation(buildName string, itemNames []string) (sim.SimulationResult,
 	return results, nil
 }
 
+// splitList splits a comma-separated flag value, trimming whitespace
+func splitList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func main() {
+	augmentFlag := flag.String("augments", "", "comma-separated augments to attach to every build")
+	componentFlag := flag.String("components", "", "comma-separated components; compares every loadout craftable from them instead of the default builds")
+	radiantFlag := flag.Bool("radiant", false, "also run a radiant version of every build")
+	delayFlag := flag.Duration("delay", 0, "time before the unit starts attacking, e.g. 1.5s to walk into range")
+	preCastFlag := flag.Bool("precast", false, "let the unit cast during -delay when it starts with full mana")
+	mechanicFlag := flag.String("mechanics", "", "comma-separated set mechanics in play for every build, e.g. \"Anomaly: Overdrive\"")
+	timelineFlag := flag.Bool("timeline", true, "save a stat timeline chart of stats, mana, item stacks and buffs for every build")
+	flag.Parse()
+
 	fmt.Println("=== TFT Simulation Build Comparison ===")
 
+	// Augments given on the command line apply to every build
+	sharedAugments := splitList(*augmentFlag)
+
 	generateIndividual := false
 
 	// Define the two builds to compare
-	builds := []struct {
+	type build struct {
 		name      string
 		itemNames []string
-	}{
+		augments  []string
+		traits    map[string]int
+	}
+	builds := []build{
 		{
 			name:      "Yunara - RB Titan IE",
 			itemNames: []string{"Guinsoos", "Titans", "IE"},
+			traits:    map[string]int{"Marksman": 2},
 		},
 		{
 			name:      "Yunara - Red Titans IE",
 			itemNames: []string{"Red", "Titans", "IE"},
+			traits:    map[string]int{"Marksman": 2},
 		},
 	}
 
+	// Components given on the command line replace the default builds with
+	// every loadout that can be crafted from them
+	if components := splitList(*componentFlag); len(components) > 0 {
+		unit, _ := units.Get("Yunara", 2)
+		loadouts, err := items.CraftableLoadouts(unit, components)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(loadouts) == 0 {
+			fmt.Println("Error: No loadouts can be crafted from the given components")
+			return
+		}
+
+		builds = builds[:0]
+		for _, loadout := range loadouts {
+			builds = append(builds, build{
+				name:      "Yunara - " + loadout.String(),
+				itemNames: loadout.Items,
+				traits:    map[string]int{"Marksman": 2},
+			})
+		}
+		fmt.Printf("Found %d craftable loadouts\n", len(loadouts))
+	}
+
+	// Pair every build with its radiant version
+	if *radiantFlag {
+		for _, b := range builds {
+			builds = append(builds, build{
+				name:      b.name + " (Radiant)",
+				itemNames: items.RadiantNames(b.itemNames),
+				augments:  b.augments,
+				traits:    b.traits,
+			})
+		}
+	}
+
 	// Run simulations for each build
 	var allResults []sim.SimulationResult
 	var buildLabels []string
+	var buildItems [][]string
 
 	for _, build := range builds {
 		fmt.Printf("\nRunning simulation for: %s\n", build.name)
-		results, err := runSimulation(build.name, build.itemNames)
+		augmentNames := append(append([]string{}, build.augments...), sharedAugments...)
+		results, err := runSimulation(build.name, build.itemNames, augmentNames, build.traits, *delayFlag, *preCastFlag, splitList(*mechanicFlag))
 		if err != nil {
 			fmt.Printf("Error running simulation for %s: %v\n", build.name, err)
 			continue
 		}
 		allResults = append(allResults, results)
 		buildLabels = append(buildLabels, build.name)
+		buildItems = append(buildItems, build.itemNames)
 	}
 
 	if len(allResults) == 0 {
@@ -94,18 +202,16 @@ func main() {
 		fmt.Printf("\nBuild %d: %s\n", i+1, buildLabels[i])
 		fmt.Printf("  Total Damage: %.1f\n", result.TotalDamage)
 		fmt.Printf("  DPS: %.1f\n", result.DPS)
+		if result.OverkillDamage > 0 {
+			fmt.Printf("  Overkill: %.1f (raw DPS %.1f)\n", result.OverkillDamage, result.RawDPS)
+		}
 		fmt.Printf("  Crit Ratio: %.1f%% \n", result.CritRate*100)
+		fmt.Printf("  Augments: %v\n", result.Augments)
+		fmt.Printf("  Active Traits: %v\n", result.ActiveTraits)
 		fmt.Printf("  Damage Breakdown:\n")
 		for dmgType, amount := range result.DamageByType {
-			typeName := "Physical"
-			switch dmgType {
-			case models.DamageTypeMagic:
-				typeName = "Magic"
-			case models.DamageTypeTrue:
-				typeName = "True"
-			}
 			percentage := (amount / result.TotalDamage) * 100
-			fmt.Printf("    %s: %.1f (%.1f%%)\n", typeName, amount, percentage)
+			fmt.Printf("    %s: %.1f (%.1f%%)\n", dmgType, amount, percentage)
 		}
 	}
 
@@ -121,26 +227,51 @@ func main() {
 
 	// Generate comparison chart
 	comparisonChart := filepath.Join(outputDir, "comparisons.png")
-	if err := output.GenerateComparisonChart(allResults, buildLabels, comparisonChart); err != nil {
+	if err := output.GenerateComparisonChart(allResults, buildLabels, comparisonChart, output.ChartOptions{}); err != nil {
 		fmt.Printf("Failed to generate comparison chart: %v\n", err)
 	} else {
 		fmt.Printf("✓ Build comparison chart saved to: %s\n", comparisonChart)
 	}
 
-	// Also generate individual charts for each build
-	if generateIndividual {
-		for i, result := range allResults {
-			buildName := strings.ReplaceAll(strings.ToLower(buildLabels[i]), " + ", "_")
-			buildName = strings.ReplaceAll(buildName, " ", "_")
+	// Generate HTML report with all builds
+	reportBuilds := make([]output.ReportBuild, len(allResults))
+	for i, result := range allResults {
+		reportBuilds[i] = output.ReportBuild{
+			Name:   buildLabels[i],
+			Items:  buildItems[i],
+			Result: result,
+		}
+	}
+	reportFile := filepath.Join(outputDir, "report.html")
+	if err := output.GenerateHTMLReport(reportBuilds, reportFile); err != nil {
+		fmt.Printf("Failed to generate HTML report: %v\n", err)
+	} else {
+		fmt.Printf("✓ HTML report saved to: %s\n", reportFile)
+	}
+
+	// Per-build charts: the stat timeline unless disabled, and the
+	// individual damage charts when enabled
+	for i, result := range allResults {
+		buildName := strings.ReplaceAll(strings.ToLower(buildLabels[i]), " + ", "_")
+		buildName = strings.ReplaceAll(buildName, " ", "_")
 
-			// Generate cumulative damage chart for this build
+		if generateIndividual {
 			cumulativeChart := filepath.Join(outputDir, fmt.Sprintf("%s_damage_over_time.png", buildName))
-			if err := output.GenerateDamageChart(result, cumulativeChart); err != nil {
+			if err := output.GenerateDamageChart(result, cumulativeChart, output.ChartOptions{}); err != nil {
 				fmt.Printf("Failed to generate cumulative damage chart for %s: %v\n", buildLabels[i], err)
 			} else {
 				fmt.Printf("✓ Individual chart for %s saved to: %s\n", buildLabels[i], cumulativeChart)
 			}
 		}
+
+		if *timelineFlag {
+			timelineChart := filepath.Join(outputDir, fmt.Sprintf("%s_stat_timeline.png", buildName))
+			if err := output.GenerateStatTimelineChart(result, timelineChart, output.ChartOptions{}); err != nil {
+				fmt.Printf("Failed to generate stat timeline chart for %s: %v\n", buildLabels[i], err)
+			} else {
+				fmt.Printf("✓ Stat timeline for %s saved to: %s\n", buildLabels[i], timelineChart)
+			}
+		}
 	}
 
 	fmt.Println("\nComparison completed successfully!")
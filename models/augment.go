@@ -1,25 +1,16 @@
 package models
 
+// Augment is a player-level bonus. Stats apply to the holder and TeamStats to
+// every unit on the team, including the holder. The trigger hooks mirror the
+// item hooks of the same name and are dispatched by the simulator.
 type Augment struct {
 	Name        string
 	Description string
 	Stats       map[StatType]float64
-}
+	TeamStats   map[StatType]float64
 
-// Predefined augments
-var Augments = map[string]Augment{
-	"Combat Training": {
-		Name:        "Combat Training",
-		Description: "Your team gains 10 Attack Damage",
-		Stats: map[StatType]float64{
-			StatAttackDamage: .10,
-		},
-	},
-	"Magic Wand": {
-		Name:        "Magic Wand",
-		Description: "Your team gains 10 Ability Power",
-		Stats: map[StatType]float64{
-			StatAbilityPower: .10,
-		},
-	},
+	OnCombatStartEffect func(*Unit)
+	OnAttackEffect      func(*Unit)
+	OnHitEffect         func(*Unit, *Target, float64)
+	OnSecondEffect      func(*Unit)
 }
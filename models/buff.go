@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 )
 
@@ -22,7 +23,8 @@ type Buff struct {
 	AppliedTime time.Duration
 	Source      interface{} // Unit, Item, or Ability that created it
 
-	// Stat modifications
+	// Stat modifications. Bonuses go to each stat's default bucket and
+	// multipliers to the final multiplier bucket, see Stats
 	StatBonuses     map[StatType]float64
 	StatMultipliers map[StatType]float64
 
@@ -42,6 +44,8 @@ type Buff struct {
 	CurrentStacks int
 	StackBehavior StackBehavior
 	IsExpired     bool
+
+	manager *BuffManager // Set once applied, notified of stat changes
 }
 
 // NewBuff creates a new buff with default values
@@ -58,18 +62,27 @@ func NewBuff(name string, duration time.Duration) *Buff {
 	}
 }
 
-// AddStatBonus adds a flat stat bonus to the buff
+// AddStatBonus adds a stat bonus to the buff, applied like Stats.AddBonus
 func (b *Buff) AddStatBonus(stat StatType, value float64) *Buff {
 	b.StatBonuses[stat] = value
+	b.statsChanged()
 	return b
 }
 
-// AddStatMultiplier adds a stat multiplier to the buff
+// AddStatMultiplier adds a final stat multiplier to the buff
 func (b *Buff) AddStatMultiplier(stat StatType, value float64) *Buff {
 	b.StatMultipliers[stat] = value
+	b.statsChanged()
 	return b
 }
 
+// statsChanged invalidates the cached buff stats of the manager holding the buff
+func (b *Buff) statsChanged() {
+	if b.manager != nil {
+		b.manager.Invalidate()
+	}
+}
+
 // SetAutoAttackOverride sets a custom auto attack function
 func (b *Buff) SetAutoAttackOverride(fn func(*Unit, *Target) float64) *Buff {
 	b.ModifiesAutoAttack = true
@@ -151,13 +164,10 @@ func (b *Buff) Refresh(currentTime time.Duration, newBuff *Buff) {
 	case StackBehaviorMultiplicative:
 		if b.CurrentStacks < b.MaxStacks {
 			b.CurrentStacks++
-			// Multiply existing stat multipliers
+			// Compound stat multipliers; they are stored as an increase
+			// over 1, so 0.1 stacked twice becomes 0.21
 			for stat, value := range newBuff.StatMultipliers {
-				if existing, ok := b.StatMultipliers[stat]; ok {
-					b.StatMultipliers[stat] = existing * (1 + value)
-				} else {
-					b.StatMultipliers[stat] = 1 + value
-				}
+				b.StatMultipliers[stat] = (1+b.StatMultipliers[stat])*(1+value) - 1
 			}
 		}
 		// For Refresh and Independent, just update duration
@@ -171,6 +181,7 @@ func (b *Buff) Refresh(currentTime time.Duration, newBuff *Buff) {
 // Expire marks the buff as expired and triggers OnExpire
 func (b *Buff) Expire(unit *Unit) {
 	b.IsExpired = true
+	b.statsChanged()
 	if b.OnExpire != nil {
 		b.OnExpire(unit)
 	}
@@ -180,6 +191,20 @@ func (b *Buff) Expire(unit *Unit) {
 type BuffManager struct {
 	Unit  *Unit
 	Buffs []*Buff
+
+	version uint64 // Bumped whenever buff stats may have changed
+	stats   buffStatsCache
+}
+
+// buffStatsCache holds summed buff stats. They stay valid until the buffs
+// change or the earliest active buff runs out at until.
+type buffStatsCache struct {
+	valid       bool
+	version     uint64
+	generation  uint64 // Bumped on every recompute, used by Stats to detect changes
+	from, until time.Duration
+	bonuses     map[StatType]float64
+	multipliers map[StatType]float64
 }
 
 // NewBuffManager creates a new buff manager for a unit
@@ -192,6 +217,8 @@ func NewBuffManager(unit *Unit) *BuffManager {
 
 // ApplyBuff applies a new buff to the unit
 func (bm *BuffManager) ApplyBuff(buff *Buff, currentTime time.Duration) {
+	defer bm.Invalidate()
+
 	// Check for existing buff with same name
 	for _, existing := range bm.Buffs {
 		if existing.Name == buff.Name && !existing.IsExpired {
@@ -203,6 +230,7 @@ func (bm *BuffManager) ApplyBuff(buff *Buff, currentTime time.Duration) {
 	// Apply new buff
 	buff.AppliedTime = currentTime
 	buff.Source = bm.Unit
+	buff.manager = bm
 	bm.Buffs = append(bm.Buffs, buff)
 
 	if buff.OnApply != nil {
@@ -210,16 +238,15 @@ func (bm *BuffManager) ApplyBuff(buff *Buff, currentTime time.Duration) {
 	}
 }
 
-// RemoveBuff removes a buff by name
+// RemoveBuff expires a buff by name. It stays in the list until the next
+// UpdateBuffs compacts it away, so it is safe to call from ForEachActiveBuff.
 func (bm *BuffManager) RemoveBuff(name string) {
-	for i, buff := range bm.Buffs {
+	for _, buff := range bm.Buffs {
 		if buff.Name == name && !buff.IsExpired {
 			buff.Expire(bm.Unit)
-			// Mark for removal
-			bm.Buffs[i].IsExpired = true
 		}
 	}
-	bm.cleanupExpired()
+	bm.Invalidate()
 }
 
 // UpdateBuffs updates all buffs and removes expired ones
@@ -241,7 +268,8 @@ func (bm *BuffManager) UpdateBuffs(currentTime time.Duration) {
 	bm.cleanupExpired()
 }
 
-// GetActiveBuffs returns all active buffs
+// GetActiveBuffs returns all active buffs in a new slice. Hot paths should
+// use ForEachActiveBuff or GetBuff, which do not allocate.
 func (bm *BuffManager) GetActiveBuffs(currentTime time.Duration) []*Buff {
 	active := make([]*Buff, 0)
 	for _, buff := range bm.Buffs {
@@ -252,42 +280,102 @@ func (bm *BuffManager) GetActiveBuffs(currentTime time.Duration) []*Buff {
 	return active
 }
 
-// HasBuff checks if unit has an active buff with given name
-func (bm *BuffManager) HasBuff(name string, currentTime time.Duration) bool {
+// ForEachActiveBuff calls fn for every active buff. Buffs applied by fn are
+// not visited, and fn may remove buffs.
+func (bm *BuffManager) ForEachActiveBuff(currentTime time.Duration, fn func(*Buff)) {
+	buffs := bm.Buffs
+	for _, buff := range buffs {
+		if !buff.IsExpired && buff.IsActive(currentTime) {
+			fn(buff)
+		}
+	}
+}
+
+// GetBuff returns the active buff with the given name, or nil
+func (bm *BuffManager) GetBuff(name string, currentTime time.Duration) *Buff {
 	for _, buff := range bm.Buffs {
 		if buff.Name == name && !buff.IsExpired && buff.IsActive(currentTime) {
-			return true
+			return buff
 		}
 	}
-	return false
+	return nil
 }
 
-// GetBuffStats calculates total stat bonuses from all active buffs
+// HasBuff checks if unit has an active buff with given name
+func (bm *BuffManager) HasBuff(name string, currentTime time.Duration) bool {
+	return bm.GetBuff(name, currentTime) != nil
+}
+
+// Invalidate drops the cached buff stats. Buff methods call it; call it
+// directly after writing to a buff's stat maps.
+func (bm *BuffManager) Invalidate() {
+	bm.version++
+}
+
+// GetBuffStats returns the total stat bonuses and multipliers from all active
+// buffs. The maps are cached and shared between calls, so callers must not
+// modify them.
 func (bm *BuffManager) GetBuffStats(currentTime time.Duration) (map[StatType]float64, map[StatType]float64) {
-	bonuses := make(map[StatType]float64)
-	multipliers := make(map[StatType]float64)
+	bm.refreshStats(currentTime)
+	return bm.stats.bonuses, bm.stats.multipliers
+}
+
+// statsGeneration returns a counter that changes whenever the buff stats
+// at currentTime differ from the last call
+func (bm *BuffManager) statsGeneration(currentTime time.Duration) uint64 {
+	bm.refreshStats(currentTime)
+	return bm.stats.generation
+}
+
+// refreshStats recomputes the cached buff stats if buffs changed or
+// currentTime is outside the window the cache was computed for
+func (bm *BuffManager) refreshStats(currentTime time.Duration) {
+	c := &bm.stats
+	if c.valid && c.version == bm.version && currentTime >= c.from && currentTime < c.until {
+		return
+	}
+
+	if c.bonuses == nil {
+		c.bonuses = make(map[StatType]float64)
+		c.multipliers = make(map[StatType]float64)
+	}
+	clear(c.bonuses)
+	clear(c.multipliers)
 
-	for _, buff := range bm.GetActiveBuffs(currentTime) {
+	c.until = time.Duration(math.MaxInt64)
+	for _, buff := range bm.Buffs {
+		if buff.IsExpired || !buff.IsActive(currentTime) {
+			continue
+		}
 		for stat, value := range buff.StatBonuses {
-			bonuses[stat] += value
+			c.bonuses[stat] += value
 		}
 		for stat, value := range buff.StatMultipliers {
-			multipliers[stat] += value
+			c.multipliers[stat] += value
+		}
+		if buff.Duration > 0 && buff.AppliedTime+buff.Duration < c.until {
+			c.until = buff.AppliedTime + buff.Duration
 		}
 	}
 
-	return bonuses, multipliers
+	c.valid = true
+	c.version = bm.version
+	c.from = currentTime
+	c.generation++
 }
 
-// cleanupExpired removes expired buffs from the list
+// cleanupExpired removes expired buffs from the list in place
 func (bm *BuffManager) cleanupExpired() {
-	active := make([]*Buff, 0)
+	n := 0
 	for _, buff := range bm.Buffs {
 		if !buff.IsExpired {
-			active = append(active, buff)
+			bm.Buffs[n] = buff
+			n++
 		}
 	}
-	bm.Buffs = active
+	// Clear the tail so removed buffs can be collected
+	clear(bm.Buffs[n:])
+	bm.Buffs = bm.Buffs[:n]
 }
 
 // Predefined buffs for common effects
@@ -308,7 +396,7 @@ var (
 	// DamageAmpBuff increases damage dealt
 	DamageAmpBuff = func(duration time.Duration, amount float64) *Buff {
 		return NewBuff("Damage Amplification", duration).
-			AddStatMultiplier(StatDamageAmp, amount).
+			AddStatBonus(StatDamageAmp, amount).
 			SetCallbacks(
 				nil, nil, nil, nil, nil,
 			)
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuffManagerHotPathDoesNotAllocate(t *testing.T) {
+	unit := newBenchUnit()
+	bm := unit.BuffManager
+	now := time.Second
+
+	allocs := testing.AllocsPerRun(100, func() {
+		bm.UpdateBuffs(now)
+		bm.ForEachActiveBuff(now, func(*Buff) {})
+		bm.GetBuff("Titans", now)
+		unit.Stats.Get(StatAttackSpeed)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations per tick, got %.1f", allocs)
+	}
+}
+
+func TestCleanupExpiredKeepsActiveBuffs(t *testing.T) {
+	bm := newItemTestUnit().BuffManager
+	bm.ApplyBuff(NewBuff("Short", time.Second), 0)
+	bm.ApplyBuff(NewBuff("Permanent", 0), 0)
+	bm.ApplyBuff(NewBuff("Long", 3*time.Second), 0)
+
+	bm.UpdateBuffs(2 * time.Second)
+
+	if len(bm.Buffs) != 2 || bm.Buffs[0].Name != "Permanent" || bm.Buffs[1].Name != "Long" {
+		t.Fatalf("Unexpected buffs after cleanup: %d", len(bm.Buffs))
+	}
+	if bm.HasBuff("Short", 2*time.Second) {
+		t.Error("Expired buff should be removed")
+	}
+}
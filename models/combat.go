@@ -22,9 +22,15 @@ func NewCritTracker() *CritTracker {
 	}
 }
 
+// Seed resets the crit RNG so runs with the same seed roll the same crits
+func (ct *CritTracker) Seed(seed int64) {
+	ct.RNG = rand.New(rand.NewSource(seed))
+}
+
+// RollCrit rolls an auto attack's crit and records it in the totals and streak
 func (ct *CritTracker) RollCrit(critChance float64) bool {
 	ct.TotalAttacks++
-	if critChance >= 1.0 || (critChance > 0 && ct.RNG.Float64() < critChance) {
+	if ct.Roll(critChance) {
 		ct.TotalCrits++
 		ct.CritStreak++
 		return true
@@ -33,6 +39,12 @@ func (ct *CritTracker) RollCrit(critChance float64) bool {
 	return false
 }
 
+// Roll rolls a crit without recording it, for ability crits that must not
+// count toward the attack crit rate or streak
+func (ct *CritTracker) Roll(critChance float64) bool {
+	return critChance >= 1.0 || (critChance > 0 && ct.RNG.Float64() < critChance)
+}
+
 func CalculateCritDamage(baseDamage, critDamageMultiplier float64, isAbility bool, hasJeweledGauntlet bool) float64 {
 	if !isAbility || hasJeweledGauntlet {
 		return baseDamage * critDamageMultiplier
@@ -41,36 +53,39 @@ func CalculateCritDamage(baseDamage, critDamageMultiplier float64, isAbility boo
 }
 
 func CalculateDamage(attacker *Unit, target *Target, resistance float64, baseDamage float64, canCrit bool) (float64, bool) {
-	// Get attacker stats
-	critChance := attacker.Stats.Get(StatCritChance)
-	critDamage := 1.0 + attacker.Stats.Get(StatCritDamage)
+	isCrit := attacker.CritTracker.RollCrit(attacker.Stats.Get(StatCritChance)) && canCrit
+	return MitigateDamage(attacker, target, resistance, baseDamage, isCrit), isCrit
+}
 
+// MitigateDamage applies an already rolled crit, damage amp, the target's
+// damage reduction and its resistance to damage
+func MitigateDamage(attacker *Unit, target *Target, resistance float64, baseDamage float64, isCrit bool) float64 {
 	totalDamage := baseDamage
 
 	// Apply crit
-	isCrit := attacker.CritTracker.RollCrit(critChance)
-	if isCrit && canCrit {
-		totalDamage *= critDamage
+	if isCrit {
+		totalDamage *= 1.0 + attacker.Stats.Get(StatCritDamage)
 	}
 
 	// Apply Amp
 	totalDamage *= 1 + attacker.Stats.Get(StatDamageAmp)
 
 	// Apply target resistances
-	var damageReduction float64
-	if resistance >= 0 {
-		damageReduction = resistance / (100 + resistance)
-	} else {
-		damageReduction = 2 - (resistance / (100 - resistance))
-	}
+	damageReduction := resistanceReduction(resistance)
 
 	// Apply damage reduction
 	totalDamage *= (1 - target.DamageReduction)
 
 	// Final damage after armor
-	finalDamage := totalDamage * (1 - damageReduction)
+	return totalDamage * (1 - damageReduction)
+}
 
-	return finalDamage, isCrit && canCrit
+// resistanceReduction converts armor or magic resist into the fraction of damage blocked
+func resistanceReduction(resistance float64) float64 {
+	if resistance >= 0 {
+		return resistance / (100 + resistance)
+	}
+	return 2 - (resistance / (100 - resistance))
 }
 
 // CalculateTrueDamage calculates true damage which ignores all resistances
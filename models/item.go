@@ -1,18 +1,97 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxItemSlots is the number of items a unit can hold
+const MaxItemSlots = 3
+
+// AbilityCritConversion is the crit damage granted by each ability crit
+// source (IE, JG) beyond the first, since ability crits can only be enabled once
+const AbilityCritConversion = 0.10
+
+// ItemCategory determines how an item is slotted
+type ItemCategory int
+
+const (
+	ItemCategoryCompleted ItemCategory = iota
+	ItemCategoryComponent
+	ItemCategoryRadiant
+	ItemCategoryArtifact
+	ItemCategoryEmblem
+	ItemCategorySupport
+)
+
+func (c ItemCategory) String() string {
+	switch c {
+	case ItemCategoryComponent:
+		return "Component"
+	case ItemCategoryRadiant:
+		return "Radiant"
+	case ItemCategoryArtifact:
+		return "Artifact"
+	case ItemCategoryEmblem:
+		return "Emblem"
+	case ItemCategorySupport:
+		return "Support"
+	default:
+		return "Completed"
+	}
+}
+
+// Item validation errors returned by Unit.AddItem
+var (
+	ErrItemSlotsFull        = errors.New("no free item slots")
+	ErrDuplicateUniqueItem  = errors.New("unique item already equipped")
+	ErrEmblemTraitDuplicate = errors.New("unit already has the emblem's trait")
+)
+
+// Item is a static item definition. Its trigger callbacks are dispatched
+// against the equipped ItemInstance at these points in combat:
+//
+//   - OnEquipEffect: when the item is added to a unit
+//   - OnCombatStartEffect: once, before the first tick
+//   - OnAttackEffect: when an auto attack fires, before its damage is applied
+//   - OnHitEffect: after an auto attack's damage is applied, with whether
+//     that attack critically struck
+//   - OnDamageDealtEffect: after any damage the holder deals, attacks and abilities alike
+//   - OnCritEffect: after any damage from the holder that critically struck
+//   - OnKillEffect: when damage from the holder kills a target
+//   - OnAbilityCast: when the holder starts casting and spends its mana
+//   - OnAbilityCastComplete: when the holder's cast finishes
+//   - OnManaFullEffect: when the holder's mana reaches its maximum
+//   - OnDamageTakenEffect: after the holder takes damage
+//   - OnHealthThresholdEffect: once per combat, the first time the holder's
+//     health falls to HealthThreshold (a fraction of max health) or below
+//   - OnSecondEffect: once per second of combat
 type Item struct {
-	Name             string
-	Description      string
-	Stats            map[StatType]float64
-	OnHitEffect      func(*ItemInstance, *Target, float64)
-	OnAttackEffect   func(*ItemInstance)
-	OnAbilityCast    func(*ItemInstance)
-	OnSecondEffect   func(*ItemInstance)
-	OnEquipEffect    func(*ItemInstance, *[]ItemInstance)
-	Unique           bool
-	AllowAbilityCrit bool
-	Stacking         bool
-	MaxStacks        int
+	Name                    string
+	Description             string
+	Stats                   map[StatType]float64
+	OnHitEffect             func(*ItemInstance, *Target, float64, bool) // Damage dealt and whether it crit
+	OnAttackEffect          func(*ItemInstance)
+	OnAbilityCast           func(*ItemInstance)
+	OnAbilityCastComplete   func(*ItemInstance)
+	OnSecondEffect          func(*ItemInstance)
+	OnEquipEffect           func(*ItemInstance, *[]ItemInstance)
+	OnCombatStartEffect     func(*ItemInstance)
+	OnDamageDealtEffect     func(*ItemInstance, *Target, float64, DamageType)
+	OnCritEffect            func(*ItemInstance, *Target, float64)
+	OnKillEffect            func(*ItemInstance, *Target)
+	OnManaFullEffect        func(*ItemInstance)
+	OnDamageTakenEffect     func(*ItemInstance, float64, DamageType)
+	OnHealthThresholdEffect func(*ItemInstance)
+	HealthThreshold         float64
+	Category                ItemCategory
+	GrantsTrait             string  // Trait added to the holder, used by emblems
+	BaseItem                string  // Item a radiant variant was generated from
+	EffectScale             float64 // Multiplier for effect values, 0 means 1
+	Unique                  bool
+	AllowAbilityCrit        bool
+	Stacking                bool
+	MaxStacks               int
 }
 
 type ItemInstance struct {
@@ -20,4 +99,57 @@ type ItemInstance struct {
 	Item       Item
 	Stacks     int
 	Owner      *Unit
+
+	// ThresholdTriggered is set once OnHealthThresholdEffect has fired this combat
+	ThresholdTriggered bool
+}
+
+// IsUnique reports whether a unit may hold only one copy of the item.
+// Artifacts, emblems and support items are always unique.
+func (i Item) IsUnique() bool {
+	switch i.Category {
+	case ItemCategoryArtifact, ItemCategoryEmblem, ItemCategorySupport:
+		return true
+	}
+	return i.Unique
+}
+
+// Scaled returns an effect value adjusted by the item's EffectScale.
+// Item callbacks should pass their hardcoded effect values through it so
+// radiant variants get stronger effects.
+func (i Item) Scaled(value float64) float64 {
+	if i.EffectScale == 0 {
+		return value
+	}
+	return value * i.EffectScale
+}
+
+// baseName is the name shared by an item and its radiant variant
+func (i Item) baseName() string {
+	if i.BaseItem != "" {
+		return i.BaseItem
+	}
+	return i.Name
+}
+
+// CanAddItem checks the item against the unit's slot, uniqueness and emblem rules
+func (u *Unit) CanAddItem(item Item) error {
+	if len(u.Items) >= MaxItemSlots {
+		return fmt.Errorf("%w: cannot add %s, %s already holds %d items", ErrItemSlotsFull, item.Name, u.Name, len(u.Items))
+	}
+
+	if item.IsUnique() {
+		for _, held := range u.Items {
+			// A radiant counts as a copy of its base item
+			if held.Item.baseName() == item.baseName() {
+				return fmt.Errorf("%w: %s (%s)", ErrDuplicateUniqueItem, item.Name, item.Category)
+			}
+		}
+	}
+
+	if item.GrantsTrait != "" && u.HasTrait(item.GrantsTrait) {
+		return fmt.Errorf("%w: %s grants %s", ErrEmblemTraitDuplicate, item.Name, item.GrantsTrait)
+	}
+
+	return nil
 }
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// newItemTestUnit creates a unit at full health with 100 AD, 60 max mana and
+// an ability that locks mana for a second, shared by the models tests
+func newItemTestUnit(traits ...string) *Unit {
+	baseStats := map[StatType]float64{
+		StatHealth:       1000,
+		StatAttackDamage: 100,
+		StatAttackSpeed:  1.0,
+		StatCritDamage:   0.4,
+		StatMana:         60,
+	}
+	unit := NewUnit(Unit{Name: "Test Unit", Traits: traits}, Ability{Name: "Nuke", ManaLock: time.Second}, baseStats, 2)
+	unit.CurrentHealth = 1000
+	return unit
+}
+
+func TestAddItemSlotLimit(t *testing.T) {
+	unit := newItemTestUnit()
+	item := Item{Name: "Sword", Stats: map[StatType]float64{StatAttackDamage: 0.1}}
+
+	for i := 0; i < MaxItemSlots; i++ {
+		if err := unit.AddItem(item); err != nil {
+			t.Fatalf("AddItem %d failed: %v", i, err)
+		}
+	}
+
+	err := unit.AddItem(item)
+	if !errors.Is(err, ErrItemSlotsFull) {
+		t.Fatalf("Expected ErrItemSlotsFull, got %v", err)
+	}
+
+	// The rejected item must not have applied its stats
+	if got := unit.Stats.Get(StatAttackDamage); math.Abs(got-130) > 1e-9 {
+		t.Errorf("Expected 130 AD from three items, got %.2f", got)
+	}
+}
+
+func TestAddItemUniqueRules(t *testing.T) {
+	cases := []struct {
+		name string
+		item Item
+	}{
+		{"unique completed", Item{Name: "IE", Unique: true}},
+		{"artifact", Item{Name: "Mittens", Category: ItemCategoryArtifact}},
+		{"support", Item{Name: "Zekes", Category: ItemCategorySupport}},
+		{"emblem", Item{Name: "Test Emblem", Category: ItemCategoryEmblem}},
+	}
+
+	for _, c := range cases {
+		unit := newItemTestUnit()
+		if err := unit.AddItem(c.item); err != nil {
+			t.Fatalf("%s: first AddItem failed: %v", c.name, err)
+		}
+		if err := unit.AddItem(c.item); !errors.Is(err, ErrDuplicateUniqueItem) {
+			t.Errorf("%s: expected ErrDuplicateUniqueItem, got %v", c.name, err)
+		}
+	}
+
+	// Non-unique completed items and radiants may be duplicated
+	for _, item := range []Item{{Name: "Red"}, {Name: "Radiant Red", Category: ItemCategoryRadiant}} {
+		unit := newItemTestUnit()
+		for i := 0; i < 2; i++ {
+			if err := unit.AddItem(item); err != nil {
+				t.Errorf("%s: AddItem %d failed: %v", item.Name, i, err)
+			}
+		}
+	}
+}
+
+func TestAddItemEmblemGrantsTrait(t *testing.T) {
+	emblem := Item{Name: "Duelist Emblem", Category: ItemCategoryEmblem, GrantsTrait: "Duelist"}
+
+	unit := newItemTestUnit("Marksman")
+	if err := unit.AddItem(emblem); err != nil {
+		t.Fatal(err)
+	}
+	if !unit.HasTrait("Duelist") {
+		t.Error("Expected emblem to grant the Duelist trait")
+	}
+
+	native := newItemTestUnit("Duelist")
+	if err := native.AddItem(emblem); !errors.Is(err, ErrEmblemTraitDuplicate) {
+		t.Errorf("Expected ErrEmblemTraitDuplicate, got %v", err)
+	}
+}
+
+func TestAddItemAbilityCritConversion(t *testing.T) {
+	unit := newItemTestUnit()
+	if err := unit.AddItem(Item{Name: "IE", Unique: true, AllowAbilityCrit: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !unit.Ability.CanAbilityCrit {
+		t.Fatal("Expected IE to enable ability crits")
+	}
+	if got := unit.Stats.Get(StatCritDamage); math.Abs(got-0.4) > 1e-9 {
+		t.Errorf("Expected first ability crit source not to add crit damage, got %.2f", got)
+	}
+
+	if err := unit.AddItem(Item{Name: "JG", Unique: true, AllowAbilityCrit: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := unit.Stats.Get(StatCritDamage); math.Abs(got-(0.4+AbilityCritConversion)) > 1e-9 {
+		t.Errorf("Expected second ability crit source to convert to crit damage, got %.2f", got)
+	}
+}
+
+func TestRadiantCountsAsBaseForUniqueness(t *testing.T) {
+	unit := newItemTestUnit()
+	if err := unit.AddItem(Item{Name: "IE", Unique: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	radiant := Item{Name: "Radiant IE", BaseItem: "IE", Category: ItemCategoryRadiant, Unique: true}
+	if err := unit.AddItem(radiant); !errors.Is(err, ErrDuplicateUniqueItem) {
+		t.Errorf("Expected ErrDuplicateUniqueItem for Radiant IE with IE, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// ManaPerAttack returns the mana gained when an auto attack lands: the
+// role's base plus any StatManaPerAttack from items and augments
+func (u *Unit) ManaPerAttack() float64 {
+	return u.UnitRole.Config().ManaPerAttack + u.Stats.Get(StatManaPerAttack)
+}
+
+// IsManaLocked reports whether the unit is unable to gain mana: while casting
+// an ability that does not allow mana gain, and for the ability's ManaLock
+// after the cast completes
+func (u *Unit) IsManaLocked(currentTime time.Duration) bool {
+	if u.CastingCtx != nil && !u.CastingCtx.CanGainMana {
+		return true
+	}
+	return currentTime < u.ManaLockedUntil
+}
+
+// ResetMana sets the unit's mana to its starting mana and clears any mana
+// lock, ready for a new combat
+func (u *Unit) ResetMana() {
+	u.CurrentMana = u.Stats.Get(StatStartingMana)
+	u.ManaLockedUntil = 0
+}
+
+// GainMana grants mana from an auto attack landing or from taking damage,
+// unless the unit is mana locked
+func (u *Unit) GainMana(fromAutoAttack bool, fromAttack float64) {
+	if u.IsManaLocked(u.Stats.CurrentTime) {
+		return
+	}
+
+	if fromAutoAttack {
+		u.AddMana(u.ManaPerAttack())
+		return
+	}
+
+	if mana := u.UnitRole.Config().DamageTakenMana; mana > 0 {
+		u.AddMana(mana)
+	}
+}
+
+// RegenMana grants one second of StatManaRegen unless the unit is mana locked
+func (u *Unit) RegenMana(currentTime time.Duration) {
+	regen := u.Stats.Get(StatManaRegen)
+	if regen <= 0 || u.IsManaLocked(currentTime) {
+		return
+	}
+	u.AddMana(regen)
+}
+
+// AddMana adds mana regardless of any mana lock and fires OnManaFullEffect
+// when the unit's mana becomes full. Mana is not capped at the max: the
+// overflow is kept and carries over past the cost of the next cast.
+func (u *Unit) AddMana(amount float64) {
+	maxMana := u.Stats.Get(StatMana)
+	wasFull := u.CurrentMana >= maxMana
+
+	u.CurrentMana += amount
+
+	if !wasFull && u.CurrentMana >= maxMana {
+		u.ForEachItem(func(item *ItemInstance) {
+			if item.Item.OnManaFullEffect != nil {
+				item.Item.OnManaFullEffect(item)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManaPerAttackAddsBonus(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.UnitRole = RoleAttackMarksman
+	unit.Stats.AddBonus(StatManaPerAttack, 5)
+	if got := unit.ManaPerAttack(); got != 15 {
+		t.Errorf("Expected bonus mana per attack to add to the role's, got %.0f", got)
+	}
+}
+
+func TestResetManaUsesStartingMana(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.Stats.AddBonus(StatStartingMana, 15)
+	unit.CurrentMana = 55
+	unit.ManaLockedUntil = time.Second
+
+	unit.ResetMana()
+	if unit.CurrentMana != 15 || unit.ManaLockedUntil != 0 {
+		t.Errorf("Expected 15 starting mana and no lock, got %.0f mana locked until %v", unit.CurrentMana, unit.ManaLockedUntil)
+	}
+}
+
+func TestManaLockAfterCast(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.Stats.SetBase(StatManaRegen, 2)
+	unit.CurrentMana = 60
+	unit.StartCastingAbility(0, nil)
+	if !unit.IsManaLocked(0) {
+		t.Error("Expected no mana gain while casting")
+	}
+
+	unit.CompleteCast(500 * time.Millisecond)
+	if !unit.IsManaLocked(time.Second) || unit.IsManaLocked(1500*time.Millisecond) {
+		t.Errorf("Expected mana locked for 1s after the cast, locked until %v", unit.ManaLockedUntil)
+	}
+
+	unit.RegenMana(time.Second)
+	unit.Stats.SetCurrentTime(time.Second)
+	unit.GainMana(true, 0)
+	if unit.CurrentMana != 0 {
+		t.Errorf("Expected no mana gained while locked, got %.0f", unit.CurrentMana)
+	}
+
+	unit.RegenMana(2 * time.Second)
+	if unit.CurrentMana != 2 {
+		t.Errorf("Expected regen once the lock ended, got %.0f", unit.CurrentMana)
+	}
+}
+
+func TestManaOverflowCarriesOver(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.UnitRole = RoleAttackMarksman
+	unit.CurrentMana = 55
+	unit.GainMana(true, 0)
+	if unit.CurrentMana != 65 {
+		t.Fatalf("Expected mana above the max to be kept, got %.0f", unit.CurrentMana)
+	}
+
+	unit.StartCastingAbility(0, nil)
+	if unit.CurrentMana != 5 {
+		t.Errorf("Expected the overflow to remain after paying the cost, got %.0f", unit.CurrentMana)
+	}
+}
+
+func TestManaFullFiresOnce(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.UnitRole = RoleAttackMarksman
+	fills := 0
+	if err := unit.AddItem(Item{Name: "Counter", OnManaFullEffect: func(*ItemInstance) { fills++ }}); err != nil {
+		t.Fatal(err)
+	}
+
+	unit.AddMana(50)
+	unit.AddMana(10)
+	if fills != 1 {
+		t.Errorf("Expected OnManaFullEffect once when mana fills, got %d", fills)
+	}
+}
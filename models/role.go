@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+type Role int
+
+const (
+	RoleAttackTank Role = iota
+	RoleAttackFighter
+	RoleAttackMarksman
+	RoleAttackCaster
+	RoleAttackAssassin
+	RoleAttackSpecialist
+	RoleHybridFighter
+	RoleMagicTank
+	RoleMagicFighter
+	RoleMagicMarksman
+	RoleMagicCaster
+	RoleMagicAssassin
+	RoleMagicSpecialist
+
+	roleCount // Number of roles, keep last
+)
+
+// Melee and ranged attack ranges in hexes, used by roles
+const (
+	meleeRange  = 1
+	rangedRange = 4
+)
+
+// RoleConfig is the behavior every unit of a role shares
+type RoleConfig struct {
+	Name            string
+	ManaPerAttack   float64       // Mana gained when an auto attack lands
+	ManaRegen       float64       // Base mana regen per second
+	DamageTakenMana float64       // Mana gained per instance of damage taken
+	AttackWindup    time.Duration // Used when the unit template sets no windup
+	AttackRange     float64       // Hexes, used when the unit template sets no range
+}
+
+// roleConfigs is indexed by Role and must list every role in order
+var roleConfigs = [roleCount]RoleConfig{
+	RoleAttackTank:       {Name: "Attack Tank", ManaPerAttack: 5, DamageTakenMana: 5, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleAttackFighter:    {Name: "Attack Fighter", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleAttackMarksman:   {Name: "Attack Marksman", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: rangedRange},
+	RoleAttackCaster:     {Name: "Attack Caster", ManaPerAttack: 7, ManaRegen: 2, AttackWindup: DefaultAttackWindup, AttackRange: rangedRange},
+	RoleAttackAssassin:   {Name: "Attack Assassin", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleAttackSpecialist: {Name: "Attack Specialist", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleHybridFighter:    {Name: "Hybrid Fighter", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleMagicTank:        {Name: "Magic Tank", ManaPerAttack: 5, DamageTakenMana: 5, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleMagicFighter:     {Name: "Magic Fighter", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleMagicMarksman:    {Name: "Magic Marksman", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: rangedRange},
+	RoleMagicCaster:      {Name: "Magic Caster", ManaPerAttack: 7, ManaRegen: 2, AttackWindup: DefaultAttackWindup, AttackRange: rangedRange},
+	RoleMagicAssassin:    {Name: "Magic Assassin", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+	RoleMagicSpecialist:  {Name: "Magic Specialist", ManaPerAttack: 10, AttackWindup: DefaultAttackWindup, AttackRange: meleeRange},
+}
+
+// Config returns the role's entry in the role table. Unknown roles get the
+// attack fighter's behavior.
+func (r Role) Config() RoleConfig {
+	if r < 0 || r >= roleCount {
+		return roleConfigs[RoleAttackFighter]
+	}
+	return roleConfigs[r]
+}
+
+func (r Role) String() string {
+	if r < 0 || r >= roleCount {
+		return "Unknown"
+	}
+	return roleConfigs[r].Name
+}
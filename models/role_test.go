@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoleTable(t *testing.T) {
+	w := DefaultAttackWindup
+	want := map[Role]RoleConfig{
+		RoleAttackTank:       {Name: "Attack Tank", ManaPerAttack: 5, DamageTakenMana: 5, AttackWindup: w, AttackRange: 1},
+		RoleAttackFighter:    {Name: "Attack Fighter", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleAttackMarksman:   {Name: "Attack Marksman", ManaPerAttack: 10, AttackWindup: w, AttackRange: 4},
+		RoleAttackCaster:     {Name: "Attack Caster", ManaPerAttack: 7, ManaRegen: 2, AttackWindup: w, AttackRange: 4},
+		RoleAttackAssassin:   {Name: "Attack Assassin", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleAttackSpecialist: {Name: "Attack Specialist", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleHybridFighter:    {Name: "Hybrid Fighter", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleMagicTank:        {Name: "Magic Tank", ManaPerAttack: 5, DamageTakenMana: 5, AttackWindup: w, AttackRange: 1},
+		RoleMagicFighter:     {Name: "Magic Fighter", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleMagicMarksman:    {Name: "Magic Marksman", ManaPerAttack: 10, AttackWindup: w, AttackRange: 4},
+		RoleMagicCaster:      {Name: "Magic Caster", ManaPerAttack: 7, ManaRegen: 2, AttackWindup: w, AttackRange: 4},
+		RoleMagicAssassin:    {Name: "Magic Assassin", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+		RoleMagicSpecialist:  {Name: "Magic Specialist", ManaPerAttack: 10, AttackWindup: w, AttackRange: 1},
+	}
+	if len(want) != int(roleCount) {
+		t.Fatalf("Expected all %d roles to be tested, got %d", roleCount, len(want))
+	}
+
+	for role := Role(0); role < roleCount; role++ {
+		if got := role.Config(); got != want[role] {
+			t.Errorf("Role %d: expected %+v, got %+v", role, want[role], got)
+		}
+		if role.String() != want[role].Name {
+			t.Errorf("Role %d: expected name %q, got %q", role, want[role].Name, role.String())
+		}
+	}
+
+	if got := Role(roleCount).String(); got != "Unknown" {
+		t.Errorf("Expected an unknown role, got %q", got)
+	}
+}
+
+func TestNewUnitUsesRoleDefaults(t *testing.T) {
+	for role := Role(0); role < roleCount; role++ {
+		config := role.Config()
+		unit := NewUnit(Unit{Name: "Unit", UnitRole: role}, Ability{}, nil, 1)
+		if unit.AttackWindup != config.AttackWindup || unit.AttackRange != config.AttackRange {
+			t.Errorf("%s: expected windup %v and range %.0f, got %v and %.0f",
+				role, config.AttackWindup, config.AttackRange, unit.AttackWindup, unit.AttackRange)
+		}
+		if got := unit.Stats.Get(StatManaRegen); got != config.ManaRegen {
+			t.Errorf("%s: expected %.0f mana regen, got %.0f", role, config.ManaRegen, got)
+		}
+		if got := unit.ManaPerAttack(); got != config.ManaPerAttack {
+			t.Errorf("%s: expected %.0f mana per attack, got %.0f", role, config.ManaPerAttack, got)
+		}
+	}
+
+	unit := NewUnit(Unit{Name: "Unit", UnitRole: RoleMagicCaster, AttackWindup: 50 * time.Millisecond, AttackRange: 6}, Ability{},
+		map[StatType]float64{StatManaRegen: 5}, 1)
+	if unit.AttackWindup != 50*time.Millisecond || unit.AttackRange != 6 || unit.Stats.Get(StatManaRegen) != 5 {
+		t.Errorf("Expected the template to override the role, got %v windup, %.0f range, %.0f regen",
+			unit.AttackWindup, unit.AttackRange, unit.Stats.Get(StatManaRegen))
+	}
+}
+
+func TestDamageTakenManaByRole(t *testing.T) {
+	for role := Role(0); role < roleCount; role++ {
+		unit := NewUnit(Unit{Name: "Unit", UnitRole: role}, Ability{}, map[StatType]float64{StatHealth: 1000, StatMana: 100}, 1)
+		unit.TakeDamage(10, DamageTypeTrue)
+		if want := role.Config().DamageTakenMana; unit.CurrentMana != want {
+			t.Errorf("%s: expected %.0f mana from damage taken, got %.0f", role, want, unit.CurrentMana)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+import "time"
+
+// DefaultAttackWindup is the role table's windup for units that do not set
+// their own AttackWindup
+const DefaultAttackWindup = 200 * time.Millisecond
+
+var unitStateNames = map[UnitState]string{
+	UnitStateIdle:       "Idle",
+	UnitStateAttacking:  "Attacking",
+	UnitStateCasting:    "Casting",
+	UnitStateChanneling: "Channeling",
+}
+
+func (s UnitState) String() string {
+	if name, ok := unitStateNames[s]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// AttackContext is an auto attack between the start of its windup and its
+// release. The zero value means no attack is winding up.
+type AttackContext struct {
+	Target      *Target
+	StartTime   time.Duration
+	ReleaseTime time.Duration
+}
+
+// StateHandler is notified of every change of Unit.State
+type StateHandler func(from, to UnitState)
+
+// SetStateHandler installs the function notified of state transitions
+func (u *Unit) SetStateHandler(handler StateHandler) {
+	u.stateHandler = handler
+}
+
+// setState moves the unit to a new state and notifies the state handler
+func (u *Unit) setState(state UnitState) {
+	if u.State == state {
+		return
+	}
+	from := u.State
+	u.State = state
+	if u.stateHandler != nil {
+		u.stateHandler(from, state)
+	}
+}
+
+// IsWindingUp reports whether an auto attack has started but not yet released
+func (u *Unit) IsWindingUp() bool {
+	return u.Attack.Target != nil
+}
+
+// GetAttackWindup returns the windup of the next auto attack. AttackWindup is
+// the windup at base attack speed and shrinks as attack speed rises, so it
+// always takes the same share of the attack interval.
+func (u *Unit) GetAttackWindup() time.Duration {
+	base := u.Stats.Base[StatAttackSpeed]
+	as := u.GetAttackSpeed()
+	if base <= 0 || as <= base {
+		return u.AttackWindup
+	}
+	return time.Duration(float64(u.AttackWindup) * base / as)
+}
+
+// MissileTravel returns how long an auto attack released now takes to reach
+// the target. It is zero for melee units.
+func (u *Unit) MissileTravel(target *Target) time.Duration {
+	if u.MissileSpeed <= 0 {
+		return 0
+	}
+	distance := target.Distance
+	if distance <= 0 {
+		distance = u.AttackRange
+	}
+	return time.Duration(distance / u.MissileSpeed * float64(time.Second))
+}
+
+// StartAttack begins the windup of an auto attack on the target. The attack
+// timer starts with the windup, so the next attack is ready one attack
+// interval later regardless of how long the windup takes.
+func (u *Unit) StartAttack(currentTime time.Duration, target *Target) {
+	u.Attack = AttackContext{
+		Target:      target,
+		StartTime:   currentTime,
+		ReleaseTime: currentTime + u.GetAttackWindup(),
+	}
+	u.NextAttackTime = currentTime + u.GetAttackInterval()
+
+	// Attacks during a cast that allows them leave the unit casting
+	if u.State == UnitStateIdle {
+		u.setState(UnitStateAttacking)
+	}
+}
+
+// ReleaseAttack ends the windup and returns the attacked target. The caller
+// resolves the attack.
+func (u *Unit) ReleaseAttack() *Target {
+	target := u.Attack.Target
+	u.Attack = AttackContext{}
+	if u.State == UnitStateAttacking {
+		u.setState(UnitStateIdle)
+	}
+	return target
+}
+
+// CancelAttack drops an attack that is still winding up, e.g. for a cast or
+// because its target died. The attack timer is refunded so the unit can
+// attack again as soon as it is free.
+func (u *Unit) CancelAttack() {
+	if !u.IsWindingUp() {
+		return
+	}
+	u.NextAttackTime = u.Attack.StartTime
+	u.Attack = AttackContext{}
+	if u.State == UnitStateAttacking {
+		u.setState(UnitStateIdle)
+	}
+}
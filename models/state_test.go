@@ -0,0 +1,105 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewUnitKeepsTemplateWindup(t *testing.T) {
+	unit := NewUnit(Unit{Name: "Quick", AttackWindup: 20 * time.Millisecond, AttackRange: 4, MissileSpeed: 20}, Ability{}, nil, 1)
+	if unit.AttackWindup != 20*time.Millisecond || unit.AttackRange != 4 || unit.MissileSpeed != 20 {
+		t.Errorf("Expected template windup, range and missile speed, got %+v", unit)
+	}
+
+	if unit := newItemTestUnit(); unit.AttackWindup != DefaultAttackWindup {
+		t.Errorf("Expected default windup, got %v", unit.AttackWindup)
+	}
+}
+
+func TestMissileTravel(t *testing.T) {
+	unit := newItemTestUnit()
+	target := NewTarget("Dummy", 1000, 0, 0)
+	if got := unit.MissileTravel(target); got != 0 {
+		t.Errorf("Expected melee attacks to land at once, got %v", got)
+	}
+
+	unit.AttackRange = 4
+	unit.MissileSpeed = 20
+	if got := unit.MissileTravel(target); got != 200*time.Millisecond {
+		t.Errorf("Expected 200ms to cross the full range, got %v", got)
+	}
+
+	target.Distance = 2
+	if got := unit.MissileTravel(target); got != 100*time.Millisecond {
+		t.Errorf("Expected 100ms to a closer target, got %v", got)
+	}
+}
+
+func TestAttackWindupScalesWithAttackSpeed(t *testing.T) {
+	unit := NewUnit(Unit{Name: "Attacker"}, Ability{}, map[StatType]float64{StatAttackSpeed: 0.8}, 1)
+	if got := unit.GetAttackWindup(); got != DefaultAttackWindup {
+		t.Errorf("Expected full windup at base attack speed, got %v", got)
+	}
+
+	unit.Stats.AddBonus(StatAttackSpeed, 1) // Doubles attack speed
+	if got := unit.GetAttackWindup(); got != DefaultAttackWindup/2 {
+		t.Errorf("Expected half windup at double attack speed, got %v", got)
+	}
+}
+
+func TestAttackStateTransitions(t *testing.T) {
+	unit := newItemTestUnit()
+	target := NewTarget("Dummy", 1000, 0, 0)
+
+	var transitions []UnitState
+	unit.SetStateHandler(func(from, to UnitState) { transitions = append(transitions, to) })
+
+	unit.StartAttack(time.Second, target)
+	if !unit.IsWindingUp() || unit.State != UnitStateAttacking {
+		t.Fatalf("Expected attack windup, got state %s", unit.State)
+	}
+	if unit.Attack.ReleaseTime != time.Second+DefaultAttackWindup || unit.NextAttackTime != time.Second+unit.GetAttackInterval() {
+		t.Errorf("Unexpected attack timing %+v, next attack %v", unit.Attack, unit.NextAttackTime)
+	}
+	if unit.CanAutoAttack(time.Hour) {
+		t.Error("Expected no new attack during a windup")
+	}
+
+	if got := unit.ReleaseAttack(); got != target || unit.IsWindingUp() || unit.State != UnitStateIdle {
+		t.Errorf("Expected release to return the target and idle, got state %s", unit.State)
+	}
+
+	// A cancelled attack refunds its timer
+	unit.StartAttack(2*time.Second, target)
+	unit.CancelAttack()
+	if unit.IsWindingUp() || unit.NextAttackTime != 2*time.Second || !unit.CanAutoAttack(2*time.Second) {
+		t.Errorf("Expected cancel to refund the attack timer, next attack %v", unit.NextAttackTime)
+	}
+
+	want := []UnitState{UnitStateAttacking, UnitStateIdle, UnitStateAttacking, UnitStateIdle}
+	if len(transitions) != len(want) {
+		t.Fatalf("Expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Transition %d: expected %s, got %s", i, want[i], transitions[i])
+		}
+	}
+}
+
+func TestCastResumesAttackStartedDuringCast(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.Ability = Ability{Name: "Stance", CastTime: time.Second, AllowsAutoAttacksDuringCast: true}
+	target := NewTarget("Dummy", 1000, 0, 0)
+
+	unit.StartCastingAbility(0, []*Target{target})
+	unit.StartAttack(500*time.Millisecond, target)
+	if unit.State != UnitStateCasting {
+		t.Errorf("Expected attacks during the cast to keep the unit casting, got %s", unit.State)
+	}
+
+	unit.CompleteCast(time.Second)
+	if unit.State != UnitStateAttacking || !unit.IsWindingUp() {
+		t.Errorf("Expected the unit to finish its attack after the cast, got %s", unit.State)
+	}
+}
@@ -18,24 +18,101 @@ const (
 	StatVamp
 	StatDamageReduction
 	StatDamageAmp
+	StatStartingMana
+	StatManaPerAttack
+
+	statCount // Number of stat types, keep last
 )
 
+var statNames = map[StatType]string{
+	StatHealth:          "Health",
+	StatArmor:           "Armor",
+	StatMagicResist:     "Magic Resist",
+	StatAttackDamage:    "Attack Damage",
+	StatAbilityPower:    "Ability Power",
+	StatAttackSpeed:     "Attack Speed",
+	StatCritChance:      "Crit Chance",
+	StatCritDamage:      "Crit Damage",
+	StatMana:            "Mana",
+	StatManaRegen:       "Mana Regen",
+	StatVamp:            "Omnivamp",
+	StatDamageReduction: "Damage Reduction",
+	StatDamageAmp:       "Damage Amp",
+	StatStartingMana:    "Starting Mana",
+	StatManaPerAttack:   "Mana Per Attack",
+}
+
+func (s StatType) String() string {
+	if name, ok := statNames[s]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// StatKind is the bucket AddBonus and buff StatBonuses apply to for a stat
+type StatKind int
+
+const (
+	// StatKindFlat bonuses are added to the base value, e.g. +20 Armor
+	StatKindFlat StatKind = iota
+	// StatKindPercent bonuses are a fraction of the base value, e.g. +10% AD
+	StatKindPercent
+)
+
+// statKinds lists the stats whose bonuses are percent of base; all others are flat
+var statKinds = map[StatType]StatKind{
+	StatAttackDamage: StatKindPercent,
+	StatAttackSpeed:  StatKindPercent,
+}
+
+// Kind returns how bonuses to the stat are applied
+func (s StatType) Kind() StatKind {
+	return statKinds[s]
+}
+
 const (
 	AttackSpeedCap = 5.0
 )
 
+// Stats holds a unit's stats split into buckets. The final value of a stat is
+//
+//	(Base + Flat) * (1 + Percent) * (1 + Multipliers)
+//
+// where each bucket also includes the matching contributions of active buffs.
+// Flat and percent bonuses are summed within their bucket, as are final
+// multipliers, so two +10% multipliers give 1.2x rather than 1.21x. Attack
+// speed is capped at AttackSpeedCap after every bucket is applied.
+//
+// Derived values are cached until a bucket changes or the unit's buff stats
+// change. Write buckets through the Set/Add methods, or call Invalidate after
+// modifying the maps directly.
 type Stats struct {
 	Base        map[StatType]float64
-	Bonus       map[StatType]float64
-	Multipliers map[StatType]float64
-	Unit        *Unit         // Reference to unit for buff calculations
-	CurrentTime time.Duration // Current simulation time for buff calculations
+	Flat        map[StatType]float64 // Flat bonuses added to base
+	Percent     map[StatType]float64 // Bonuses as a fraction of base
+	Multipliers map[StatType]float64 // Final multipliers, additive over 1
+	Unit        *Unit                // Reference to unit for buff calculations
+	CurrentTime time.Duration        // Current simulation time for buff calculations
+
+	version uint64
+	cache   statsCache
+}
+
+// statsCache holds derived stat values for one stats version and buff
+// stats generation
+type statsCache struct {
+	version        uint64
+	buffs          *BuffManager
+	buffGeneration uint64
+	values         [statCount]float64
+	computed       [statCount]bool
 }
 
 func NewStats() Stats {
 	return Stats{
 		Base:        make(map[StatType]float64),
-		Bonus:       make(map[StatType]float64),
+		Flat:        make(map[StatType]float64),
+		Percent:     make(map[StatType]float64),
 		Multipliers: make(map[StatType]float64),
 		Unit:        nil,
 		CurrentTime: 0,
@@ -52,38 +129,79 @@ func (s *Stats) SetCurrentTime(currentTime time.Duration) {
 	s.CurrentTime = currentTime
 }
 
-func (s *Stats) GetBonus(stat StatType) float64 {
-	if s.Unit != nil && s.Unit.BuffManager != nil {
-		buffBonuses, _ := s.Unit.BuffManager.GetBuffStats(s.CurrentTime)
-		return s.Bonus[stat] + buffBonuses[stat]
+// Invalidate drops cached derived values after direct writes to the buckets
+func (s *Stats) Invalidate() {
+	s.version++
+}
+
+// buffManager returns the buff manager contributing to these stats, if any
+func (s *Stats) buffManager() *BuffManager {
+	if s.Unit == nil {
+		return nil
 	}
+	return s.Unit.BuffManager
+}
 
-	return s.Bonus[stat]
+// buffStats returns the stat bonuses and multipliers of the unit's active buffs
+func (s *Stats) buffStats() (map[StatType]float64, map[StatType]float64) {
+	if bm := s.buffManager(); bm != nil {
+		return bm.GetBuffStats(s.CurrentTime)
+	}
+	return nil, nil
+}
+
+// GetBonus returns the total bonus in the stat's default bucket, including
+// buffs: the percent bonus for percent stats and the flat bonus otherwise
+func (s *Stats) GetBonus(stat StatType) float64 {
+	buffBonuses, _ := s.buffStats()
+	if stat.Kind() == StatKindPercent {
+		return s.Percent[stat] + buffBonuses[stat]
+	}
+	return s.Flat[stat] + buffBonuses[stat]
 }
 
+// Get returns the final value of a stat
 func (s *Stats) Get(stat StatType) float64 {
-	base := s.Base[stat]
-	bonus := s.Bonus[stat]
-	multiplier := s.Multipliers[stat]
+	if stat < 0 || stat >= statCount {
+		return s.compute(stat)
+	}
 
-	if multiplier == 0 {
-		multiplier = 1.0
+	bm := s.buffManager()
+	var generation uint64
+	if bm != nil {
+		generation = bm.statsGeneration(s.CurrentTime)
 	}
 
-	// Add buff bonuses if unit is available
-	if s.Unit != nil && s.Unit.BuffManager != nil {
-		buffBonuses, buffMultipliers := s.Unit.BuffManager.GetBuffStats(s.CurrentTime)
-		bonus += buffBonuses[stat]
-		multiplier += buffMultipliers[stat]
+	c := &s.cache
+	if c.version != s.version || c.buffs != bm || c.buffGeneration != generation {
+		*c = statsCache{version: s.version, buffs: bm, buffGeneration: generation}
 	}
+	if !c.computed[stat] {
+		c.values[stat] = s.compute(stat)
+		c.computed[stat] = true
+	}
+	return c.values[stat]
+}
 
-	result := (base + bonus) * multiplier
+// compute calculates a stat's final value from its buckets and buffs
+func (s *Stats) compute(stat StatType) float64 {
+	flat := s.Flat[stat]
+	percent := s.Percent[stat]
+	multiplier := s.Multipliers[stat]
 
-	if stat == StatAttackSpeed || stat == StatAttackDamage {
-		result = base * (1 + bonus)
-		if stat == StatAttackSpeed && result > AttackSpeedCap {
-			return AttackSpeedCap
-		}
+	// Buff bonuses go to the stat's default bucket
+	buffBonuses, buffMultipliers := s.buffStats()
+	if stat.Kind() == StatKindPercent {
+		percent += buffBonuses[stat]
+	} else {
+		flat += buffBonuses[stat]
+	}
+	multiplier += buffMultipliers[stat]
+
+	result := (s.Base[stat] + flat) * (1 + percent) * (1 + multiplier)
+
+	if stat == StatAttackSpeed && result > AttackSpeedCap {
+		return AttackSpeedCap
 	}
 
 	return result
@@ -91,12 +209,32 @@ func (s *Stats) Get(stat StatType) float64 {
 
 func (s *Stats) SetBase(stat StatType, value float64) {
 	s.Base[stat] = value
+	s.version++
 }
 
+// AddBonus adds a bonus to the stat's default bucket, see StatType.Kind
 func (s *Stats) AddBonus(stat StatType, value float64) {
-	s.Bonus[stat] += value
+	if stat.Kind() == StatKindPercent {
+		s.AddPercent(stat, value)
+		return
+	}
+	s.AddFlat(stat, value)
+}
+
+// AddFlat adds a flat bonus regardless of the stat's default bucket
+func (s *Stats) AddFlat(stat StatType, value float64) {
+	s.Flat[stat] += value
+	s.version++
+}
+
+// AddPercent adds a percent-of-base bonus regardless of the stat's default bucket
+func (s *Stats) AddPercent(stat StatType, value float64) {
+	s.Percent[stat] += value
+	s.version++
 }
 
+// AddMultiplier adds a final multiplier; 0.2 scales the stat by a further 20%
 func (s *Stats) AddMultiplier(stat StatType, value float64) {
 	s.Multipliers[stat] += value
+	s.version++
 }
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// allStats lists every StatType so new stats must be added to the tests
+var allStats = []StatType{
+	StatHealth, StatArmor, StatMagicResist, StatAttackDamage, StatAbilityPower,
+	StatAttackSpeed, StatCritChance, StatCritDamage, StatMana, StatManaRegen,
+	StatVamp, StatDamageReduction, StatDamageAmp, StatStartingMana, StatManaPerAttack,
+}
+
+func TestAllStatsNamed(t *testing.T) {
+	if len(allStats) != len(statNames) {
+		t.Fatalf("allStats has %d entries but %d stats are named", len(allStats), len(statNames))
+	}
+	for _, stat := range allStats {
+		if stat.String() == "Unknown" {
+			t.Errorf("Stat %d has no name", stat)
+		}
+	}
+}
+
+func TestStatKinds(t *testing.T) {
+	for _, stat := range allStats {
+		want := StatKindFlat
+		if stat == StatAttackDamage || stat == StatAttackSpeed {
+			want = StatKindPercent
+		}
+		if got := stat.Kind(); got != want {
+			t.Errorf("%s kind = %d, want %d", stat, got, want)
+		}
+	}
+}
+
+func TestStatFormulaForEveryStat(t *testing.T) {
+	for _, stat := range allStats {
+		unit := newItemTestUnit()
+		stats := &unit.Stats
+		stats.SetBase(stat, 1.0)
+
+		stats.AddFlat(stat, 0.5)
+		stats.AddPercent(stat, 0.2)
+		stats.AddMultiplier(stat, 0.1)
+
+		// Buffs add to the default bucket and the multiplier bucket
+		buff := NewBuff("Test", 0).AddStatBonus(stat, 0.2).AddStatMultiplier(stat, 0.1)
+		unit.BuffManager.ApplyBuff(buff, 0)
+
+		flat, percent := 0.5, 0.2
+		if stat.Kind() == StatKindPercent {
+			percent += 0.2
+		} else {
+			flat += 0.2
+		}
+		want := (1.0 + flat) * (1 + percent) * (1 + 0.2)
+
+		if got := stats.Get(stat); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s = %.4f, want %.4f", stat, got, want)
+		}
+	}
+}
+
+func TestAddBonusUsesDefaultBucket(t *testing.T) {
+	for _, stat := range allStats {
+		stats := NewStats()
+		stats.SetBase(stat, 2)
+		stats.AddBonus(stat, 0.5)
+
+		want := 2.5
+		if stat.Kind() == StatKindPercent {
+			want = 3
+		}
+		if got := stats.Get(stat); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s with 0.5 bonus = %.2f, want %.2f", stat, got, want)
+		}
+		if got := stats.GetBonus(stat); got != 0.5 {
+			t.Errorf("%s GetBonus = %.2f, want 0.5", stat, got)
+		}
+	}
+}
+
+func TestAttackSpeedCap(t *testing.T) {
+	stats := NewStats()
+	stats.SetBase(StatAttackSpeed, 1.0)
+	stats.AddBonus(StatAttackSpeed, 3.0)
+	stats.AddMultiplier(StatAttackSpeed, 0.5)
+
+	if got := stats.Get(StatAttackSpeed); got != AttackSpeedCap {
+		t.Errorf("Expected attack speed capped at %.1f, got %.2f", AttackSpeedCap, got)
+	}
+}
+
+func TestBuffMultiplierAppliesToAttackDamage(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.BuffManager.ApplyBuff(NewBuff("Rage", time.Second).AddStatMultiplier(StatAttackDamage, 0.25), 0)
+
+	if got := unit.Stats.Get(StatAttackDamage); math.Abs(got-125) > 1e-9 {
+		t.Errorf("Expected 125 AD with a 25%% multiplier buff, got %.2f", got)
+	}
+}
+
+func TestMultiplicativeBuffStacking(t *testing.T) {
+	unit := newItemTestUnit()
+	for i := 0; i < 2; i++ {
+		buff := NewBuff("Compound", 0).AddStatMultiplier(StatAttackDamage, 0.1)
+		buff.SetStacking(5, StackBehaviorMultiplicative)
+		unit.BuffManager.ApplyBuff(buff, 0)
+	}
+
+	// Two 10% stacks compound to 21%
+	if got := unit.Stats.Get(StatAttackDamage); math.Abs(got-121) > 1e-9 {
+		t.Errorf("Expected 121 AD from two compounding stacks, got %.4f", got)
+	}
+}
+
+func TestDamageAmpBuff(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.BuffManager.ApplyBuff(DamageAmpBuff(time.Second, 0.15), 0)
+
+	if got := unit.Stats.Get(StatDamageAmp); math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("Expected 0.15 damage amp from DamageAmpBuff, got %.4f", got)
+	}
+}
+
+func TestStatsCacheInvalidation(t *testing.T) {
+	unit := newItemTestUnit()
+	stats := &unit.Stats
+	check := func(step string, want float64) {
+		t.Helper()
+		if got := stats.Get(StatAttackDamage); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: AD = %.2f, want %.2f", step, got, want)
+		}
+	}
+
+	check("base", 100)
+
+	stats.AddBonus(StatAttackDamage, 0.1)
+	check("after bonus", 110)
+
+	buff := NewBuff("Timed", 2*time.Second).AddStatBonus(StatAttackDamage, 0.2)
+	unit.BuffManager.ApplyBuff(buff, 0)
+	check("after buff applied", 130)
+
+	// Changing an applied buff's stats, as stacking items do
+	buff.AddStatBonus(StatAttackDamage, 0.3)
+	check("after buff changed", 140)
+
+	// The buff runs out without UpdateBuffs being called
+	stats.SetCurrentTime(2 * time.Second)
+	check("after buff expired", 110)
+
+	stats.Base[StatAttackDamage] = 200
+	stats.Invalidate()
+	check("after direct write", 220)
+}
+
+// newBenchUnit returns a unit with a few active buffs, similar to a unit
+// mid-combat with item and trait buffs
+func newBenchUnit() *Unit {
+	unit := newItemTestUnit()
+	for _, name := range []string{"Titans", "Krakens", "Trait", "Augment"} {
+		buff := NewBuff(name, 0).AddStatBonus(StatAttackDamage, 0.1).AddStatBonus(StatAttackSpeed, 0.1)
+		unit.BuffManager.ApplyBuff(buff, 0)
+	}
+	unit.BuffManager.ApplyBuff(DamageAmpBuff(time.Hour, 0.1), 0)
+	return unit
+}
+
+func BenchmarkStatsGet(b *testing.B) {
+	unit := newBenchUnit()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, stat := range allStats {
+			unit.Stats.Get(stat)
+		}
+	}
+}
+
+// BenchmarkStatsGetWithUpdates interleaves reads with a bonus change, as a
+// stacking item does once per attack
+func BenchmarkStatsGetWithUpdates(b *testing.B) {
+	unit := newBenchUnit()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		unit.Stats.AddBonus(StatAttackSpeed, 0.001)
+		for _, stat := range allStats {
+			unit.Stats.Get(stat)
+		}
+	}
+}
@@ -1,11 +1,23 @@
 package models
 
+import (
+	"math"
+	"time"
+)
+
 type Target struct {
 	Name            string
 	Stats           Stats
 	DamageReduction float64 // Percentage (0-1)
 	CurrentHP       float64
 	MaxHP           float64
+
+	// Distance is how far the target is from the attacking unit in hexes.
+	// Zero means the unit attacks from its full attack range.
+	Distance float64
+
+	// StunnedUntil is when the last stun on the target ends
+	StunnedUntil time.Duration
 }
 
 func NewTarget(name string, hp, armor, mr float64) *Target {
@@ -24,16 +36,37 @@ func NewTarget(name string, hp, armor, mr float64) *Target {
 	return t
 }
 
-func (t *Target) TakeDamage(damage float64, damageType DamageType) float64 {
-	t.CurrentHP -= damage
+// TakeDamage removes damage from the target's HP. It returns the effective
+// damage, which actually removed HP, and the overkill beyond the HP the target
+// had left; together they add up to damage.
+func (t *Target) TakeDamage(damage float64, damageType DamageType) (effective, overkill float64) {
+	if damage > t.CurrentHP {
+		effective = math.Max(t.CurrentHP, 0)
+		overkill = damage - effective
+	} else {
+		effective = damage
+	}
 
+	t.CurrentHP -= effective
 	if t.IsDead() {
 		t.CurrentHP = 0
 	}
 
-	return damage
+	return effective, overkill
 }
 
 func (t *Target) IsDead() bool {
 	return t.CurrentHP <= 0
 }
+
+// Stun stuns the target until the given time, keeping a longer stun
+func (t *Target) Stun(until time.Duration) {
+	if until > t.StunnedUntil {
+		t.StunnedUntil = until
+	}
+}
+
+// IsStunned reports whether the target is stunned at the given time
+func (t *Target) IsStunned(currentTime time.Duration) bool {
+	return currentTime < t.StunnedUntil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTargetTakeDamageSplitsOverkill(t *testing.T) {
+	target := NewTarget("Dummy", 100, 0, 0)
+
+	if effective, overkill := target.TakeDamage(60, DamageTypePhysical); effective != 60 || overkill != 0 {
+		t.Errorf("Expected 60 effective damage, got %.0f and %.0f overkill", effective, overkill)
+	}
+	if effective, overkill := target.TakeDamage(70, DamageTypePhysical); effective != 40 || overkill != 30 {
+		t.Errorf("Expected 40 effective and 30 overkill, got %.0f and %.0f", effective, overkill)
+	}
+	if !target.IsDead() || target.CurrentHP != 0 {
+		t.Errorf("Expected a dead target at 0 HP, got %.0f", target.CurrentHP)
+	}
+
+	// Everything dealt to a dead target is overkill
+	if effective, overkill := target.TakeDamage(25, DamageTypeMagic); effective != 0 || overkill != 25 {
+		t.Errorf("Expected only overkill on a dead target, got %.0f and %.0f", effective, overkill)
+	}
+}
+
+func TestTargetStunKeepsLongest(t *testing.T) {
+	target := NewTarget("Dummy", 100, 0, 0)
+	target.Stun(2 * time.Second)
+	target.Stun(time.Second)
+	if !target.IsStunned(1500*time.Millisecond) || target.IsStunned(2*time.Second) {
+		t.Errorf("Expected the longer stun to last until 2s, got %v", target.StunnedUntil)
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import "fmt"
+
+// Trait is a synergy that grants effects once enough units with it are fielded
+type Trait struct {
+	Name        string
+	Description string
+	// TeamWide traits affect every unit; others only affect units that declare the trait
+	TeamWide    bool
+	Breakpoints []TraitBreakpoint // Sorted by ascending Count
+}
+
+// TraitBreakpoint is the set of effects granted at a given trait count
+type TraitBreakpoint struct {
+	Count          int
+	Stats          map[StatType]float64          // Applied when the trait is added
+	Buffs          []func() *Buff                // Applied at combat start
+	OnSecondEffect func(*Unit)                   // Called once per second of combat
+	OnHitEffect    func(*Unit, *Target, float64) // Called after each auto attack lands
+}
+
+// ActiveTrait is a trait that reached a breakpoint for a unit
+type ActiveTrait struct {
+	Name       string
+	Count      int
+	Breakpoint TraitBreakpoint
+}
+
+func (a ActiveTrait) String() string {
+	return fmt.Sprintf("%s (%d)", a.Name, a.Count)
+}
+
+// Breakpoint returns the highest breakpoint reached with count units
+func (t Trait) Breakpoint(count int) (TraitBreakpoint, bool) {
+	var reached TraitBreakpoint
+	found := false
+	for _, bp := range t.Breakpoints {
+		if count >= bp.Count {
+			reached = bp
+			found = true
+		}
+	}
+	return reached, found
+}
+
+// HasTrait checks if the unit declares the named trait
+func (u *Unit) HasTrait(name string) bool {
+	for _, trait := range u.Traits {
+		if trait == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTrait activates a trait at the given count and applies its stat bonuses.
+// Returns false if the count does not reach any breakpoint.
+func (u *Unit) AddTrait(trait Trait, count int) bool {
+	bp, ok := trait.Breakpoint(count)
+	if !ok {
+		return false
+	}
+
+	u.ActiveTraits = append(u.ActiveTraits, ActiveTrait{
+		Name:       trait.Name,
+		Count:      count,
+		Breakpoint: bp,
+	})
+
+	// Apply trait stats
+	for stat, value := range bp.Stats {
+		u.Stats.AddBonus(stat, value)
+	}
+
+	return true
+}
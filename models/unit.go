@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -14,24 +15,20 @@ const (
 	DamageTypeTrue
 )
 
-type Role int
-
-const (
-	RoleAttackTank Role = iota
-	RoleAttackFighter
-	RoleAttackMarksman
-	RoleAttackCaster
-	RoleAttackAssassin
-	RoleAttackSpecialist
-	RoleHybridFighter
-	RoleMagicTank
-	RoleMagicFighter
-	RoleMagicMarksman
-	RoleMagicCaster
-	RoleMagicAssassin
-	RoleMagicSpecialist
-)
+func (d DamageType) String() string {
+	switch d {
+	case DamageTypeMagic:
+		return "Magic"
+	case DamageTypeTrue:
+		return "True"
+	default:
+		return "Physical"
+	}
+}
 
+// UnitState is what a unit is currently locked into. An attack winding up
+// holds the unit in UnitStateAttacking, except during a cast that allows auto
+// attacks, where the unit stays in UnitStateCasting. See state.go.
 type UnitState int
 
 const (
@@ -63,18 +60,30 @@ type Ability struct {
 	IsAutoAttackModifier        bool
 	AllowsManaGainDuringCast    bool
 	AllowsAutoAttacksDuringCast bool
+	ManaLock                    time.Duration // Time after the cast completes without mana gain, see IsManaLocked
 	OnCast                      func(*Unit, []*Target)
 	OnCastStart                 func(*Unit)
 	OnCastComplete              func(*Unit, []*Target)
 	CanAbilityCrit              bool
 }
 
+// DamageHandler applies damage dealt by a unit to a target and returns the damage dealt.
+// The simulator installs one so that ability damage is logged and fires item triggers.
+type DamageHandler func(target *Target, damage float64, damageType DamageType, isAbility, isCrit bool) float64
+
 type Unit struct {
-	Name        string
-	Stats       Stats
-	UnitRole    Role
-	StarLevel   int
-	CurrentMana float64
+	Name          string
+	Stats         Stats
+	UnitRole      Role
+	StarLevel     int
+	CurrentMana   float64 // May exceed max mana, see AddMana
+	CurrentHealth float64
+
+	// Shield absorbs damage taken before health until ShieldExpires
+	Shield        float64
+	ShieldExpires time.Duration
+	// ManaLockedUntil is when the mana lock of the last cast ends
+	ManaLockedUntil time.Duration
 
 	// State
 	State      UnitState
@@ -82,8 +91,15 @@ type Unit struct {
 
 	// Attack mechanics
 	AttackTimer    time.Duration
-	AttackWindup   time.Duration
+	AttackWindup   time.Duration // Windup at base attack speed, see GetAttackWindup
 	NextAttackTime time.Duration
+	Attack         AttackContext // The attack currently winding up, if any
+
+	// Ranged units launch a missile that travels to the target, see
+	// MissileTravel. Melee units have no missile speed and their attacks
+	// land on release.
+	AttackRange  float64 // Hexes
+	MissileSpeed float64 // Hexes per second, zero for melee
 
 	// Ability
 	Ability Ability
@@ -92,19 +108,32 @@ type Unit struct {
 	Items    []ItemInstance
 	Augments []Augment
 
+	// Traits declared by the unit and the traits active in the current scenario
+	Traits       []string
+	ActiveTraits []ActiveTrait
+
 	// Buff system
 	BuffManager *BuffManager
 
 	// Combat tracking
-	TotalDamage  float64
-	DamageLog    []DamageEvent
-	AttackCount  int
-	AbilityCount int
-	CritTracker  *CritTracker
+	TotalDamage    float64 // Effective damage, excluding overkill
+	OverkillDamage float64 // Damage beyond the remaining HP of its target
+	DamageLog      []DamageEvent
+	AttackCount    int
+	AbilityCount   int
+	CritTracker    *CritTracker
+
+	// Verbose enables combat log output from abilities and buffs. The
+	// simulator sets it from SimulationConfig.Verbose.
+	Verbose bool
+
+	damageHandler DamageHandler
+	stateHandler  StateHandler
 }
 type DamageEvent struct {
 	Timestamp  time.Duration
-	Damage     float64
+	Damage     float64 // Effective damage that removed HP
+	Overkill   float64 // Damage beyond the target's remaining HP, not in Damage
 	DamageType DamageType
 	IsAbility  bool
 	TargetName string
@@ -117,30 +146,38 @@ func NewUnit(newUnit Unit, newAbility Ability, baseStats map[StatType]float64, s
 		Stats:          NewStats(),
 		UnitRole:       newUnit.UnitRole,
 		StarLevel:      newUnit.StarLevel,
+		Traits:         newUnit.Traits,
 		CurrentMana:    newUnit.CurrentMana,
 		AttackTimer:    0,
-		AttackWindup:   200 * time.Millisecond,
+		AttackWindup:   newUnit.AttackWindup,
+		AttackRange:    newUnit.AttackRange,
+		MissileSpeed:   newUnit.MissileSpeed,
 		DamageLog:      make([]DamageEvent, 0),
 		CritTracker:    NewCritTracker(),
 		NextAttackTime: 0,
 		BuffManager:    NewBuffManager(nil), // Will set unit reference after creation
 	}
+	role := newUnit.UnitRole.Config()
+	if unit.AttackWindup <= 0 {
+		unit.AttackWindup = role.AttackWindup
+	}
+	if unit.AttackRange <= 0 {
+		unit.AttackRange = role.AttackRange
+	}
 
 	// Set the unit reference in BuffManager
 	unit.BuffManager.Unit = unit
 	// Set the unit reference in Stats
 	unit.Stats.SetUnit(unit)
 
-	// Set base stats
+	// Set base stats, the role's mana regen unless the unit sets its own
+	unit.Stats.SetBase(StatManaRegen, role.ManaRegen)
 	for stat, value := range baseStats {
 		unit.Stats.SetBase(stat, value)
 	}
 
-	switch newUnit.UnitRole {
-	case RoleAttackCaster:
-	case RoleMagicCaster:
-		unit.Stats.SetBase(StatManaRegen, 2)
-	}
+	// The template's CurrentMana is the mana the unit starts each combat with
+	unit.Stats.SetBase(StatStartingMana, newUnit.CurrentMana)
 
 	unit.Ability = newAbility
 
@@ -154,6 +191,10 @@ func (u *Unit) GetAttackDamage() float64 {
 }
 
 func (u *Unit) CanAutoAttack(currentTime time.Duration) bool {
+	if u.IsWindingUp() {
+		return false
+	}
+
 	if u.State == UnitStateCasting && !u.CastingCtx.CanAutoAttack {
 		return false
 	}
@@ -186,7 +227,7 @@ func (u *Unit) CanCastAbility() bool {
 }
 
 func (u *Unit) StartCastingAbility(currentTime time.Duration, targets []*Target) {
-	u.State = UnitStateCasting
+	u.setState(UnitStateCasting)
 	u.CastingCtx = &CastingContext{
 		Ability:       &u.Ability,
 		StartTime:     currentTime,
@@ -196,6 +237,9 @@ func (u *Unit) StartCastingAbility(currentTime time.Duration, targets []*Target)
 		Targets:       targets,
 		IsAbilityCast: !u.Ability.IsAutoAttack,
 	}
+	if u.CastingCtx.IsAbilityCast {
+		u.AbilityCount++
+	}
 
 	// Spend mana immediately
 	if u.Stats.Get(StatMana) > 0 {
@@ -206,6 +250,12 @@ func (u *Unit) StartCastingAbility(currentTime time.Duration, targets []*Target)
 	if u.Ability.OnCastStart != nil {
 		u.Ability.OnCastStart(u)
 	}
+
+	u.ForEachItem(func(item *ItemInstance) {
+		if item.Item.OnAbilityCast != nil {
+			item.Item.OnAbilityCast(item)
+		}
+	})
 }
 
 func (u *Unit) CompleteCast(currentTime time.Duration) {
@@ -223,39 +273,135 @@ func (u *Unit) CompleteCast(currentTime time.Duration) {
 		u.Ability.OnCastComplete(u, u.CastingCtx.Targets)
 	}
 
-	// Reset state
-	u.State = UnitStateIdle
+	u.ForEachItem(func(item *ItemInstance) {
+		if item.Item.OnAbilityCastComplete != nil {
+			item.Item.OnAbilityCastComplete(item)
+		}
+	})
+
+	// Reset state, resuming an attack started during the cast
+	u.ManaLockedUntil = currentTime + u.Ability.ManaLock
 	u.CastingCtx = nil
+	if u.IsWindingUp() {
+		u.setState(UnitStateAttacking)
+	} else {
+		u.setState(UnitStateIdle)
+	}
 }
 
-func (u *Unit) GainMana(fromAutoAttack bool, fromAttack float64) {
-	if u.CastingCtx != nil && !u.CastingCtx.CanGainMana {
-		return
+// ForEachItem calls fn with a pointer to each equipped item instance so
+// that changes to Stacks and other instance state persist
+func (u *Unit) ForEachItem(fn func(*ItemInstance)) {
+	for i := range u.Items {
+		fn(&u.Items[i])
+	}
+}
+
+// SetDamageHandler installs the function used by DealDamage
+func (u *Unit) SetDamageHandler(handler DamageHandler) {
+	u.damageHandler = handler
+}
+
+// DealDamage applies damage from this unit to a target and returns the
+// effective damage, excluding overkill. Abilities should use it instead of
+// Target.TakeDamage so the simulator can log the damage and fire damage,
+// crit and kill triggers.
+func (u *Unit) DealDamage(target *Target, damage float64, damageType DamageType, isAbility, isCrit bool) float64 {
+	if u.damageHandler != nil {
+		return u.damageHandler(target, damage, damageType, isAbility, isCrit)
 	}
 
-	if fromAutoAttack {
-		switch u.UnitRole {
-		case RoleAttackTank:
-		case RoleMagicTank:
-			u.CurrentMana += 5
-		case RoleAttackCaster:
-		case RoleMagicCaster:
-			u.CurrentMana += 7
-		default:
-			u.CurrentMana += 10
+	actualDamage, overkill := target.TakeDamage(damage, damageType)
+	u.TotalDamage += actualDamage
+	u.OverkillDamage += overkill
+	u.DamageLog = append(u.DamageLog, DamageEvent{
+		Timestamp:  u.Stats.CurrentTime,
+		Damage:     actualDamage,
+		Overkill:   overkill,
+		DamageType: damageType,
+		IsAbility:  isAbility,
+		IsCrit:     isCrit,
+		TargetName: target.Name,
+	})
+	return actualDamage
+}
+
+// TakeDamage applies incoming damage to the unit after armor, magic resist
+// and damage reduction, then fires damage-taken and health threshold triggers.
+// An active shield absorbs the damage before health. Returns the damage taken,
+// including any absorbed by the shield.
+func (u *Unit) TakeDamage(damage float64, damageType DamageType) float64 {
+	switch damageType {
+	case DamageTypePhysical:
+		damage *= 1 - resistanceReduction(u.Stats.Get(StatArmor))
+	case DamageTypeMagic:
+		damage *= 1 - resistanceReduction(u.Stats.Get(StatMagicResist))
+	}
+	if damageType != DamageTypeTrue {
+		damage *= 1 - u.Stats.Get(StatDamageReduction)
+	}
+
+	u.CurrentHealth -= u.absorbDamage(damage)
+	if u.CurrentHealth < 0 {
+		u.CurrentHealth = 0
+	}
+
+	u.ForEachItem(func(item *ItemInstance) {
+		if item.Item.OnDamageTakenEffect != nil {
+			item.Item.OnDamageTakenEffect(item, damage, damageType)
 		}
-	} else {
-		if u.UnitRole == RoleAttackTank || u.UnitRole == RoleMagicTank {
-			// I don't know how taking damage mana works
-			println(fromAttack)
-			u.CurrentMana += 5
+	})
+
+	maxHealth := u.Stats.Get(StatHealth)
+	u.ForEachItem(func(item *ItemInstance) {
+		if item.Item.OnHealthThresholdEffect == nil || item.ThresholdTriggered || maxHealth <= 0 {
+			return
 		}
+		if u.CurrentHealth/maxHealth <= item.Item.HealthThreshold {
+			item.ThresholdTriggered = true
+			item.Item.OnHealthThresholdEffect(item)
+		}
+	})
+
+	u.GainMana(false, damage)
+
+	return damage
+}
+
+// absorbDamage removes damage from an active shield and returns the damage
+// left over for health
+func (u *Unit) absorbDamage(damage float64) float64 {
+	if u.Shield <= 0 || u.Stats.CurrentTime >= u.ShieldExpires {
+		u.Shield = 0
+		return damage
 	}
+	absorbed := math.Min(u.Shield, damage)
+	u.Shield -= absorbed
+	return damage - absorbed
+}
 
-	maxMana := u.Stats.Get(StatMana)
-	if u.CurrentMana > maxMana {
-		u.CurrentMana = maxMana
+// AddShield grants a shield lasting duration from the current time. Shields
+// do not stack: a new shield replaces a weaker one.
+func (u *Unit) AddShield(amount float64, duration time.Duration) {
+	if u.Shield > 0 && u.Stats.CurrentTime < u.ShieldExpires && u.Shield >= amount {
+		return
 	}
+	u.Shield = amount
+	u.ShieldExpires = u.Stats.CurrentTime + duration
+}
+
+// Heal restores health up to the unit's max health and returns the health
+// restored
+func (u *Unit) Heal(amount float64) float64 {
+	missing := math.Max(u.Stats.Get(StatHealth)-u.CurrentHealth, 0)
+	healed := math.Min(amount, missing)
+	u.CurrentHealth += healed
+	return healed
+}
+
+// IsDead reports whether the unit has run out of health
+func (u *Unit) IsDead() bool {
+	return u.CurrentHealth <= 0
 }
 
 func (u *Unit) GetAttackInterval() time.Duration {
@@ -270,7 +416,14 @@ func (u *Unit) GetAttackInterval() time.Duration {
 	return time.Duration(intervalMs) * time.Millisecond
 }
 
-func (u *Unit) AddItem(item Item) {
+// AddItem equips an item and applies its stats. It returns an error wrapping
+// ErrItemSlotsFull, ErrDuplicateUniqueItem or ErrEmblemTraitDuplicate if the
+// item cannot be equipped, in which case the unit is left unchanged.
+func (u *Unit) AddItem(item Item) error {
+	if err := u.CanAddItem(item); err != nil {
+		return err
+	}
+
 	itemInstance := ItemInstance{
 		UniqueName: strings.Join([]string{item.Name, strconv.Itoa(len(u.Items))}, ""),
 		Item:       item,
@@ -283,16 +436,24 @@ func (u *Unit) AddItem(item Item) {
 		u.Stats.AddBonus(stat, value)
 	}
 
+	// Only the first source enables ability crits, any further source
+	// (including an ability that crits natively) converts to crit damage
 	if item.AllowAbilityCrit {
 		if u.Ability.CanAbilityCrit {
-			u.Stats.AddBonus(StatCritDamage, .1)
+			u.Stats.AddBonus(StatCritDamage, AbilityCritConversion)
 		}
-		u.Ability.CanAbilityCrit = item.AllowAbilityCrit
+		u.Ability.CanAbilityCrit = true
+	}
+
+	if item.GrantsTrait != "" {
+		u.Traits = append(u.Traits, item.GrantsTrait)
 	}
 
 	if item.OnEquipEffect != nil {
 		item.OnEquipEffect(&itemInstance, &u.Items)
 	}
+
+	return nil
 }
 
 func (u *Unit) AddAugment(augment Augment) {
@@ -301,4 +462,7 @@ func (u *Unit) AddAugment(augment Augment) {
 	for stat, value := range augment.Stats {
 		u.Stats.AddBonus(stat, value)
 	}
+	for stat, value := range augment.TeamStats {
+		u.Stats.AddBonus(stat, value)
+	}
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShieldAbsorbsDamage(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.AddShield(100, 2*time.Second)
+	unit.AddShield(50, 5*time.Second) // Weaker, ignored
+
+	if taken := unit.TakeDamage(60, DamageTypeTrue); taken != 60 || unit.CurrentHealth != 1000 || unit.Shield != 40 {
+		t.Errorf("Expected the shield to absorb 60, got %.0f health and %.0f shield", unit.CurrentHealth, unit.Shield)
+	}
+	unit.TakeDamage(60, DamageTypeTrue)
+	if unit.CurrentHealth != 980 || unit.Shield != 0 {
+		t.Errorf("Expected 20 damage past the shield, got %.0f health and %.0f shield", unit.CurrentHealth, unit.Shield)
+	}
+
+	unit.AddShield(100, time.Second)
+	unit.Stats.SetCurrentTime(time.Second)
+	if unit.TakeDamage(10, DamageTypeTrue); unit.CurrentHealth != 970 {
+		t.Errorf("Expected an expired shield to absorb nothing, got %.0f health", unit.CurrentHealth)
+	}
+}
+
+func TestHealCapsAtMaxHealth(t *testing.T) {
+	unit := newItemTestUnit()
+	unit.CurrentHealth = 900
+	if healed := unit.Heal(150); healed != 100 || unit.CurrentHealth != 1000 {
+		t.Errorf("Expected 100 healed up to max health, got %.0f and %.0f health", healed, unit.CurrentHealth)
+	}
+}
@@ -0,0 +1,123 @@
+package output
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"tft-sim/sim/analysis"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// GenerateBreakEvenChart plots both builds' mean metric with 95% error bars
+// across the analysed range. Each crossover is marked with a dashed line
+// and its uncertainty interval is shaded.
+func GenerateBreakEvenChart(result analysis.BreakEvenResult, filename string, opts ChartOptions) error {
+	p, err := newBreakEvenPlot(result, opts.theme())
+	if err != nil {
+		return err
+	}
+
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 6*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newBreakEvenPlot builds the plot used by GenerateBreakEvenChart
+func newBreakEvenPlot(result analysis.BreakEvenResult, theme Theme) (*plot.Plot, error) {
+	if len(result.Curve) == 0 {
+		return nil, fmt.Errorf("no break-even curve to plot")
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("Break-even: %s vs %s", result.Builds[0].Name, result.Builds[1].Name)
+	p.X.Label.Text = result.Dimension.String()
+	p.Y.Label.Text = result.Metric.String()
+	p.Legend.Top = true
+
+	// Compute every build's points first so the crossover bands can span
+	// the full range and be drawn underneath the curves
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	var curves [2]curveErrors
+	for i := range result.Builds {
+		curves[i] = make(curveErrors, len(result.Curve))
+		for j, point := range result.Curve {
+			s := point.Builds[i]
+			low, high := s.CI95()
+			curves[i][j] = curveError{x: point.Value, y: s.Mean, err: high - s.Mean}
+			yMin = math.Min(yMin, low)
+			yMax = math.Max(yMax, high)
+		}
+	}
+
+	var markers []*plotter.Line
+	for _, crossover := range result.Crossovers {
+		band, err := plotter.NewPolygon(plotter.XYs{
+			{X: crossover.Interval[0], Y: yMin},
+			{X: crossover.Interval[1], Y: yMin},
+			{X: crossover.Interval[1], Y: yMax},
+			{X: crossover.Interval[0], Y: yMax},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create crossover interval: %w", err)
+		}
+		band.Color = color.NRGBA{0x80, 0x80, 0x80, 0x30}
+		band.LineStyle.Width = 0
+		p.Add(band)
+
+		marker, err := plotter.NewLine(plotter.XYs{{X: crossover.Value, Y: yMin}, {X: crossover.Value, Y: yMax}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create crossover marker: %w", err)
+		}
+		marker.Color = theme.Foreground
+		marker.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		markers = append(markers, marker)
+	}
+
+	for i, b := range result.Builds {
+		line, scatter, err := plotter.NewLinePoints(curves[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create line for %s: %w", b.Name, err)
+		}
+		bars, err := plotter.NewYErrorBars(curves[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create error bars for %s: %w", b.Name, err)
+		}
+
+		c := theme.Color(i)
+		line.Color = c
+		line.Width = vg.Points(1.5)
+		scatter.GlyphStyle.Color = c
+		scatter.GlyphStyle.Radius = vg.Points(2)
+		scatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[i]
+		bars.Color = c
+
+		p.Add(line, scatter, bars)
+		p.Legend.Add(b.Name, line)
+	}
+
+	for i, marker := range markers {
+		crossover := result.Crossovers[i]
+		p.Add(marker)
+		p.Legend.Add(fmt.Sprintf("Crossover at %.4g, %s ahead above", crossover.Value, result.Builds[crossover.Leader].Name), marker)
+	}
+
+	return p, nil
+}
+
+// curveError is a point with a symmetric vertical error
+type curveError struct {
+	x, y, err float64
+}
+
+// curveErrors implements plotter.XYer and plotter.YErrorer
+type curveErrors []curveError
+
+func (c curveErrors) Len() int                         { return len(c) }
+func (c curveErrors) XY(i int) (x, y float64)          { return c[i].x, c[i].y }
+func (c curveErrors) YError(i int) (low, high float64) { return c[i].err, c[i].err }
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/sim/analysis"
+	"tft-sim/sim/sweep"
+)
+
+func TestGenerateBreakEvenChart(t *testing.T) {
+	summary := func(mean float64) analysis.Summary { return analysis.Summary{Mean: mean, StdErr: 1, N: 4} }
+	result := analysis.BreakEvenResult{
+		Builds:    [2]analysis.Build{{Name: "A"}, {Name: "B"}},
+		Dimension: sweep.DimArmor,
+		Metric:    sweep.MetricDPS,
+		Curve: []analysis.CurvePoint{
+			{Value: 0, Builds: [2]analysis.Summary{summary(100), summary(80)}},
+			{Value: 100, Builds: [2]analysis.Summary{summary(60), summary(70)}},
+		},
+		Crossovers: []analysis.Crossover{{Value: 66, Leader: 1, Interval: [2]float64{0, 100}}},
+	}
+
+	filename := filepath.Join(t.TempDir(), "breakeven.svg")
+	if err := GenerateBreakEvenChart(result, filename, ChartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Error("Break-even chart was not written")
+	}
+
+	if err := GenerateBreakEvenChart(analysis.BreakEvenResult{}, filename, ChartOptions{}); err == nil {
+		t.Error("Expected error for an empty result")
+	}
+}
@@ -13,8 +13,23 @@ import (
 	"gonum.org/v1/plot/vg"
 )
 
-// GenerateDamageChart creates a PNG chart of cumulative damage over time
-func GenerateDamageChart(results sim.SimulationResult, filename string) error {
+// GenerateDamageChart creates a chart of cumulative damage over time
+func GenerateDamageChart(results sim.SimulationResult, filename string, opts ChartOptions) error {
+	p, err := newDamagePlot(results, opts.theme())
+	if err != nil {
+		return err
+	}
+
+	// Save to file
+	if err := saveChart(p, filename, opts, 8*vg.Inch, 6*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newDamagePlot builds the cumulative damage plot used by GenerateDamageChart
+func newDamagePlot(results sim.SimulationResult, theme Theme) (*plot.Plot, error) {
 	p := plot.New()
 	p.Title.Text = "Damage Over Time"
 	p.X.Label.Text = "Time (seconds)"
@@ -29,30 +44,40 @@ func GenerateDamageChart(results sim.SimulationResult, filename string) error {
 
 	line, scatter, err := plotter.NewLinePoints(pts)
 	if err != nil {
-		return fmt.Errorf("failed to create line and scatter plot: %w", err)
+		return nil, fmt.Errorf("failed to create line and scatter plot: %w", err)
 	}
-	line.Color = plotutil.Color(0)
+	line.Color = theme.Color(0)
 	line.Width = vg.Points(1.5)
 	line.StepStyle = plotter.NoStep // Ensure straight lines between points (not smoothed)
 
 	// Style the scatter points
-	scatter.GlyphStyle.Color = plotutil.Color(0)
+	scatter.GlyphStyle.Color = theme.Color(0)
 	scatter.GlyphStyle.Radius = vg.Points(2.5)
 	scatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[0]
 
 	p.Add(line, scatter)
 	p.Legend.Add("Total Damage", line)
 
+	return p, nil
+}
+
+// GenerateDamageByTypeChart creates a multi-series chart showing damage by type over time
+func GenerateDamageByTypeChart(results sim.SimulationResult, filename string, opts ChartOptions) error {
+	p, err := newDamageByTypePlot(results, opts.theme())
+	if err != nil {
+		return err
+	}
+
 	// Save to file
-	if err := p.Save(8*vg.Inch, 6*vg.Inch, filename); err != nil {
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 8*vg.Inch); err != nil {
 		return fmt.Errorf("failed to save chart: %w", err)
 	}
 
 	return nil
 }
 
-// GenerateDamageByTypeChart creates a multi-series chart showing damage by type over time
-func GenerateDamageByTypeChart(results sim.SimulationResult, filename string) error {
+// newDamageByTypePlot builds the per-damage-type plot used by GenerateDamageByTypeChart
+func newDamageByTypePlot(results sim.SimulationResult, theme Theme) (*plot.Plot, error) {
 	p := plot.New()
 	p.Title.Text = "Damage by Type Over Time"
 	p.X.Label.Text = "Time (seconds)"
@@ -72,9 +97,7 @@ func GenerateDamageByTypeChart(results sim.SimulationResult, filename string) er
 
 	for _, dot := range results.DamageOverTime {
 		// Update cumulative damage for each type
-		for dmgType, amount := range dot.DamageByType {
-			cumulativeByType[dmgType] += amount
-		}
+		cumulativeByType[dot.DamageType] += dot.InstantDamage
 
 		// Add points for each type
 		t := dot.Timestamp.Seconds()
@@ -86,34 +109,34 @@ func GenerateDamageByTypeChart(results sim.SimulationResult, filename string) er
 	// Create lines and scatters for each damage type
 	physicalLine, physicalScatter, err := plotter.NewLinePoints(physicalPts)
 	if err != nil {
-		return fmt.Errorf("failed to create physical damage line and scatter: %w", err)
+		return nil, fmt.Errorf("failed to create physical damage line and scatter: %w", err)
 	}
-	physicalLine.Color = plotutil.Color(0) // Blue
+	physicalLine.Color = theme.Color(0) // Blue
 	physicalLine.Width = vg.Points(1.5)
 	physicalLine.StepStyle = plotter.NoStep // Ensure straight lines between points (not smoothed)
-	physicalScatter.GlyphStyle.Color = plotutil.Color(0)
+	physicalScatter.GlyphStyle.Color = theme.Color(0)
 	physicalScatter.GlyphStyle.Radius = vg.Points(2.5)
 	physicalScatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[0]
 
 	magicLine, magicScatter, err := plotter.NewLinePoints(magicPts)
 	if err != nil {
-		return fmt.Errorf("failed to create magic damage line and scatter: %w", err)
+		return nil, fmt.Errorf("failed to create magic damage line and scatter: %w", err)
 	}
-	magicLine.Color = plotutil.Color(1) // Red
+	magicLine.Color = theme.Color(1) // Red
 	magicLine.Width = vg.Points(1.5)
 	magicLine.StepStyle = plotter.NoStep // Ensure straight lines between points (not smoothed)
-	magicScatter.GlyphStyle.Color = plotutil.Color(1)
+	magicScatter.GlyphStyle.Color = theme.Color(1)
 	magicScatter.GlyphStyle.Radius = vg.Points(2.5)
 	magicScatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[1]
 
 	trueLine, trueScatter, err := plotter.NewLinePoints(truePts)
 	if err != nil {
-		return fmt.Errorf("failed to create true damage line and scatter: %w", err)
+		return nil, fmt.Errorf("failed to create true damage line and scatter: %w", err)
 	}
-	trueLine.Color = plotutil.Color(2) // Green
+	trueLine.Color = theme.Color(2) // Green
 	trueLine.Width = vg.Points(1.5)
 	trueLine.StepStyle = plotter.NoStep // Ensure straight lines between points (not smoothed)
-	trueScatter.GlyphStyle.Color = plotutil.Color(2)
+	trueScatter.GlyphStyle.Color = theme.Color(2)
 	trueScatter.GlyphStyle.Radius = vg.Points(2.5)
 	trueScatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[2]
 
@@ -123,16 +146,26 @@ func GenerateDamageByTypeChart(results sim.SimulationResult, filename string) er
 	p.Legend.Add("True Damage", trueLine)
 	p.Legend.Top = true
 
+	return p, nil
+}
+
+// GenerateDPSChart creates a chart showing damage per second over time
+func GenerateDPSChart(results sim.SimulationResult, filename string, windowSize float64, opts ChartOptions) error {
+	p, err := newDPSPlot(results, windowSize, opts.theme())
+	if err != nil {
+		return err
+	}
+
 	// Save to file
-	if err := p.Save(10*vg.Inch, 8*vg.Inch, filename); err != nil {
+	if err := saveChart(p, filename, opts, 8*vg.Inch, 6*vg.Inch); err != nil {
 		return fmt.Errorf("failed to save chart: %w", err)
 	}
 
 	return nil
 }
 
-// GenerateDPSChart creates a chart showing damage per second over time
-func GenerateDPSChart(results sim.SimulationResult, filename string, windowSize float64) error {
+// newDPSPlot builds the sliding-window DPS plot used by GenerateDPSChart
+func newDPSPlot(results sim.SimulationResult, windowSize float64, theme Theme) (*plot.Plot, error) {
 	if windowSize <= 0 {
 		windowSize = 1.0 // Default 1-second window
 	}
@@ -143,7 +176,7 @@ func GenerateDPSChart(results sim.SimulationResult, filename string, windowSize
 	p.Y.Label.Text = "DPS"
 
 	if len(results.DamageOverTime) == 0 {
-		return fmt.Errorf("no damage data available")
+		return nil, fmt.Errorf("no damage data available")
 	}
 
 	// Calculate DPS using sliding window
@@ -176,34 +209,44 @@ func GenerateDPSChart(results sim.SimulationResult, filename string, windowSize
 
 	line, scatter, err := plotter.NewLinePoints(dpsPts)
 	if err != nil {
-		return fmt.Errorf("failed to create DPS line and scatter: %w", err)
+		return nil, fmt.Errorf("failed to create DPS line and scatter: %w", err)
 	}
-	line.Color = plotutil.Color(3) // Purple
+	line.Color = theme.Color(3) // Purple
 	line.Width = vg.Points(1.5)
 	line.StepStyle = plotter.NoStep // Ensure straight lines between points (not smoothed)
-	scatter.GlyphStyle.Color = plotutil.Color(3)
+	scatter.GlyphStyle.Color = theme.Color(3)
 	scatter.GlyphStyle.Radius = vg.Points(2.5)
 	scatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[3]
 
 	p.Add(line, scatter)
 	p.Legend.Add("DPS", line)
 
+	return p, nil
+}
+
+// GenerateComparisonChart creates a chart comparing cumulative damage over time for multiple builds
+func GenerateComparisonChart(results []sim.SimulationResult, labels []string, filename string, opts ChartOptions) error {
+	p, err := newComparisonPlot(results, labels, opts.theme())
+	if err != nil {
+		return err
+	}
+
 	// Save to file
-	if err := p.Save(8*vg.Inch, 6*vg.Inch, filename); err != nil {
-		return fmt.Errorf("failed to save chart: %w", err)
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 8*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save comparison chart: %w", err)
 	}
 
 	return nil
 }
 
-// GenerateComparisonChart creates a chart comparing cumulative damage over time for multiple builds
-func GenerateComparisonChart(results []sim.SimulationResult, labels []string, filename string) error {
+// newComparisonPlot builds the multi-build plot used by GenerateComparisonChart
+func newComparisonPlot(results []sim.SimulationResult, labels []string, theme Theme) (*plot.Plot, error) {
 	if len(results) != len(labels) {
-		return fmt.Errorf("number of results (%d) must match number of labels (%d)", len(results), len(labels))
+		return nil, fmt.Errorf("number of results (%d) must match number of labels (%d)", len(results), len(labels))
 	}
 
 	if len(results) == 0 {
-		return fmt.Errorf("no results provided for comparison")
+		return nil, fmt.Errorf("no results provided for comparison")
 	}
 
 	p := plot.New()
@@ -228,11 +271,11 @@ func GenerateComparisonChart(results []sim.SimulationResult, labels []string, fi
 
 		line, scatter, err := plotter.NewLinePoints(pts)
 		if err != nil {
-			return fmt.Errorf("failed to create line and scatter for build %d: %w", i, err)
+			return nil, fmt.Errorf("failed to create line and scatter for build %d: %w", i, err)
 		}
 
 		// Use different colors for each build
-		color := plotutil.Color(i)
+		color := theme.Color(i)
 		line.Color = color
 		line.Width = vg.Points(1.5)
 		line.StepStyle = plotter.PostStep // Ensure straight lines between points (not smoothed)
@@ -246,12 +289,7 @@ func GenerateComparisonChart(results []sim.SimulationResult, labels []string, fi
 		p.Legend.Add(labels[i], line)
 	}
 
-	// Save to file
-	if err := p.Save(10*vg.Inch, 8*vg.Inch, filename); err != nil {
-		return fmt.Errorf("failed to save comparison chart: %w", err)
-	}
-
-	return nil
+	return p, nil
 }
 
 type fiveUnitTicks struct{}
@@ -0,0 +1,185 @@
+package output
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ChartFormat is the file format a chart is written in
+type ChartFormat string
+
+const (
+	FormatPNG ChartFormat = "png"
+	FormatSVG ChartFormat = "svg"
+	FormatPDF ChartFormat = "pdf"
+	FormatEPS ChartFormat = "eps"
+)
+
+// DefaultDPI is the raster resolution used when ChartOptions.DPI is zero
+const DefaultDPI = 96
+
+// ChartOptions controls how a chart is rendered and saved.
+// The zero value keeps each chart's default size, title and theme and
+// infers the format from the filename extension.
+type ChartOptions struct {
+	Format ChartFormat // Inferred from the filename extension when empty
+	Width  vg.Length   // Chart width, chart default when zero
+	Height vg.Length   // Chart height, chart default when zero
+	DPI    int         // Raster resolution, only used for PNG
+	Title  string      // Overrides the chart's default title when set
+	Theme  *Theme      // Defaults to LightTheme
+}
+
+// Theme is the color scheme applied to a chart
+type Theme struct {
+	Name       string
+	Background color.Color
+	Foreground color.Color
+	Palette    []color.Color
+}
+
+var (
+	// LightTheme matches gonum's default look
+	LightTheme = Theme{
+		Name:       "light",
+		Background: color.White,
+		Foreground: color.Black,
+		Palette:    plotutil.DefaultColors,
+	}
+
+	// DarkTheme is a light-on-dark scheme for slides and dark pages
+	DarkTheme = Theme{
+		Name:       "dark",
+		Background: color.RGBA{R: 0x1e, G: 0x1e, B: 0x24, A: 0xff},
+		Foreground: color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff},
+		Palette: []color.Color{
+			color.RGBA{R: 0x4f, G: 0xc3, B: 0xf7, A: 0xff},
+			color.RGBA{R: 0xff, G: 0x8a, B: 0x65, A: 0xff},
+			color.RGBA{R: 0x81, G: 0xc7, B: 0x84, A: 0xff},
+			color.RGBA{R: 0xba, G: 0x68, B: 0xc8, A: 0xff},
+			color.RGBA{R: 0xff, G: 0xd5, B: 0x4f, A: 0xff},
+			color.RGBA{R: 0x4d, G: 0xd0, B: 0xe1, A: 0xff},
+			color.RGBA{R: 0xf0, G: 0x62, B: 0x92, A: 0xff},
+		},
+	}
+)
+
+// Color returns the i-th series color, wrapping around the palette
+func (t Theme) Color(i int) color.Color {
+	if len(t.Palette) == 0 {
+		return plotutil.Color(i)
+	}
+	return t.Palette[i%len(t.Palette)]
+}
+
+// theme returns the selected theme, falling back to LightTheme
+func (o ChartOptions) theme() Theme {
+	if o.Theme == nil {
+		return LightTheme
+	}
+	return *o.Theme
+}
+
+// format resolves the output format from the options or the filename
+func (o ChartOptions) format(filename string) (ChartFormat, error) {
+	format := o.Format
+	if format == "" {
+		ext := strings.ToLower(filepath.Ext(filename))
+		if ext == "" {
+			return FormatPNG, nil
+		}
+		format = ChartFormat(ext[1:])
+	}
+
+	switch format {
+	case FormatPNG, FormatSVG, FormatPDF, FormatEPS:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported chart format %q", format)
+	}
+}
+
+// applyTheme colors the plot background, text and axes
+func applyTheme(p *plot.Plot, theme Theme) {
+	p.BackgroundColor = theme.Background
+	p.Title.TextStyle.Color = theme.Foreground
+	p.Legend.TextStyle.Color = theme.Foreground
+	for _, axis := range []*plot.Axis{&p.X, &p.Y} {
+		axis.Color = theme.Foreground
+		axis.Label.TextStyle.Color = theme.Foreground
+		axis.Tick.Color = theme.Foreground
+		axis.Tick.Label.Color = theme.Foreground
+	}
+}
+
+// saveChart applies the options to a plot and writes it to filename.
+// defaultWidth and defaultHeight are used when the options leave the size unset.
+func saveChart(p *plot.Plot, filename string, opts ChartOptions, defaultWidth, defaultHeight vg.Length) error {
+	if opts.Title != "" {
+		p.Title.Text = opts.Title
+	}
+	applyTheme(p, opts.theme())
+
+	return saveDrawing(filename, opts, defaultWidth, defaultHeight, p.Draw)
+}
+
+// saveDrawing writes whatever drawFn renders to filename using the options'
+// format, size and DPI. It is used directly by multi-panel charts.
+func saveDrawing(filename string, opts ChartOptions, defaultWidth, defaultHeight vg.Length, drawFn func(draw.Canvas)) error {
+	format, err := opts.format(filename)
+	if err != nil {
+		return err
+	}
+
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = defaultWidth
+	}
+	if height <= 0 {
+		height = defaultHeight
+	}
+
+	writer, err := chartWriter(format, width, height, opts.DPI, drawFn)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := writer.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// chartWriter renders drawFn onto a canvas for the given format
+func chartWriter(format ChartFormat, width, height vg.Length, dpi int, drawFn func(draw.Canvas)) (io.WriterTo, error) {
+	if format != FormatPNG {
+		c, err := draw.NewFormattedCanvas(width, height, string(format))
+		if err != nil {
+			return nil, err
+		}
+		drawFn(draw.New(c))
+		return c, nil
+	}
+
+	if dpi <= 0 {
+		dpi = DefaultDPI
+	}
+	c := vgimg.NewWith(vgimg.UseWH(width, height), vgimg.UseDPI(dpi))
+	drawFn(draw.New(c))
+	return vgimg.PngCanvas{Canvas: c}, nil
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/sim"
+	"time"
+)
+
+func TestChartFormatInference(t *testing.T) {
+	cases := []struct {
+		opts     ChartOptions
+		filename string
+		want     ChartFormat
+	}{
+		{ChartOptions{}, "chart.png", FormatPNG},
+		{ChartOptions{}, "chart.SVG", FormatSVG},
+		{ChartOptions{}, "chart.pdf", FormatPDF},
+		{ChartOptions{}, "chart.eps", FormatEPS},
+		{ChartOptions{}, "chart", FormatPNG},
+		{ChartOptions{Format: FormatSVG}, "chart.png", FormatSVG},
+	}
+
+	for _, c := range cases {
+		got, err := c.opts.format(c.filename)
+		if err != nil {
+			t.Errorf("format(%q) returned error: %v", c.filename, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("format(%q) = %q, want %q", c.filename, got, c.want)
+		}
+	}
+
+	if _, err := (ChartOptions{}).format("chart.bmp"); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+}
+
+func TestGenerateDamageChartFormats(t *testing.T) {
+	results := sim.SimulationResult{
+		DamageOverTime: []sim.DamageOverTime{
+			{Timestamp: 0, CumulativeDamage: 100, InstantDamage: 100},
+			{Timestamp: time.Second, CumulativeDamage: 250, InstantDamage: 150},
+		},
+	}
+
+	markers := map[string][]byte{
+		"chart.png": []byte("\x89PNG"),
+		"chart.svg": []byte("<svg"),
+		"chart.pdf": []byte("%PDF"),
+		"chart.eps": []byte("EPSF"),
+	}
+
+	dir := t.TempDir()
+	for name, marker := range markers {
+		filename := filepath.Join(dir, name)
+		opts := ChartOptions{Title: "Custom Title", Theme: &DarkTheme, DPI: 150}
+		if err := GenerateDamageChart(results, filename, opts); err != nil {
+			t.Fatalf("GenerateDamageChart(%s) failed: %v", name, err)
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if !bytes.Contains(data, marker) {
+			t.Errorf("%s does not contain %q", name, marker)
+		}
+	}
+}
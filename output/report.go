@@ -0,0 +1,312 @@
+package output
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+)
+
+// ReportBuild is a single build to include in an HTML report
+type ReportBuild struct {
+	Name   string
+	Items  []string
+	Result sim.SimulationResult
+}
+
+// reportRow is a label/value pair rendered in a summary table
+type reportRow struct {
+	Label string
+	Value string
+}
+
+// reportSection holds the rendered content for one build
+type reportSection struct {
+	Name        string
+	Items       []string
+	Augments    []string
+	Traits      []string
+	Mechanics   []string
+	Summary     []reportRow
+	Details     []reportRow
+	FinalStats  []reportRow
+	TypeChart   template.URL
+	DPSChart    template.URL
+	ChartErrors []string
+}
+
+// reportData is the root value passed to the report template
+type reportData struct {
+	Title           string
+	SummaryHeaders  []string
+	Generated       string
+	ComparisonChart template.URL
+	Builds          []reportSection
+}
+
+// GenerateHTMLReport writes a self-contained HTML report comparing builds.
+// Charts are embedded as base64 PNG data URIs and styles are inlined, so the
+// file can be opened without network access.
+func GenerateHTMLReport(builds []ReportBuild, filename string) error {
+	if len(builds) == 0 {
+		return fmt.Errorf("no builds provided for report")
+	}
+
+	results := make([]sim.SimulationResult, len(builds))
+	labels := make([]string, len(builds))
+	for i, build := range builds {
+		results[i] = build.Result
+		labels[i] = build.Name
+	}
+
+	data := reportData{
+		Title:          "TFT Simulation Build Comparison",
+		SummaryHeaders: summaryHeaders,
+		Generated:      time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	comparison, err := newComparisonPlot(results, labels, LightTheme)
+	if err != nil {
+		return err
+	}
+	data.ComparisonChart, err = plotDataURI(comparison, 10*vg.Inch, 8*vg.Inch)
+	if err != nil {
+		return fmt.Errorf("failed to render comparison chart: %w", err)
+	}
+
+	for _, build := range builds {
+		section := reportSection{
+			Name:       build.Name,
+			Items:      build.Items,
+			Augments:   build.Result.Augments,
+			Traits:     build.Result.ActiveTraits,
+			Mechanics:  build.Result.Mechanics,
+			Summary:    summaryRows(build.Result),
+			Details:    detailRows(build.Result),
+			FinalStats: finalStatRows(build.Result),
+		}
+
+		// A build without damage still gets a summary, just no charts
+		if p, err := newDamageByTypePlot(build.Result, LightTheme); err != nil {
+			section.ChartErrors = append(section.ChartErrors, err.Error())
+		} else if section.TypeChart, err = plotDataURI(p, 8*vg.Inch, 6*vg.Inch); err != nil {
+			section.ChartErrors = append(section.ChartErrors, err.Error())
+		}
+
+		if p, err := newDPSPlot(build.Result, 1.0, LightTheme); err != nil {
+			section.ChartErrors = append(section.ChartErrors, err.Error())
+		} else if section.DPSChart, err = plotDataURI(p, 8*vg.Inch, 6*vg.Inch); err != nil {
+			section.ChartErrors = append(section.ChartErrors, err.Error())
+		}
+
+		data.Builds = append(data.Builds, section)
+	}
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+
+	return nil
+}
+
+// plotDataURI renders a plot to PNG and returns it as a data URI
+func plotDataURI(p *plot.Plot, width, height vg.Length) (template.URL, error) {
+	applyTheme(p, LightTheme)
+	writer, err := chartWriter(FormatPNG, width, height, DefaultDPI, p.Draw)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if _, err := writer.WriteTo(&buf); err != nil {
+		return "", err
+	}
+
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+// summaryHeaders are the column names matching summaryRows
+var summaryHeaders = []string{"Total Damage", "DPS", "Crit Rate", "Attacks", "Ability Casts", "Time to Kill"}
+
+// summaryRows builds the headline numbers shown in the comparison table
+func summaryRows(result sim.SimulationResult) []reportRow {
+	return []reportRow{
+		{summaryHeaders[0], fmt.Sprintf("%.1f", result.TotalDamage)},
+		{summaryHeaders[1], fmt.Sprintf("%.1f", result.DPS)},
+		{summaryHeaders[2], fmt.Sprintf("%.1f%%", result.CritRate*100)},
+		{summaryHeaders[3], fmt.Sprintf("%d", result.AttackCount)},
+		{summaryHeaders[4], fmt.Sprintf("%d", result.AbilityCount)},
+		{summaryHeaders[5], summaryTTK(result)},
+	}
+}
+
+// summaryTTK is the time to kill every target, or how many were killed when
+// some survived
+func summaryTTK(result sim.SimulationResult) string {
+	if len(result.TimeToKill) == 0 {
+		return "-"
+	}
+
+	var last time.Duration
+	killed := 0
+	for _, ttk := range result.TimeToKill {
+		if ttk < 0 {
+			continue
+		}
+		killed++
+		if ttk > last {
+			last = ttk
+		}
+	}
+
+	if killed < len(result.TimeToKill) {
+		return fmt.Sprintf("not killed (%d/%d)", killed, len(result.TimeToKill))
+	}
+	return fmt.Sprintf("%.2fs", last.Seconds())
+}
+
+// detailRows builds the per-build damage breakdown and time-to-kill rows
+func detailRows(result sim.SimulationResult) []reportRow {
+	var rows []reportRow
+
+	for _, dmgType := range sortedDamageTypes(result) {
+		amount := result.DamageByType[dmgType]
+		percentage := 0.0
+		if result.TotalDamage > 0 {
+			percentage = amount / result.TotalDamage * 100
+		}
+		rows = append(rows, reportRow{
+			Label: fmt.Sprintf("%s Damage", dmgType),
+			Value: fmt.Sprintf("%.1f (%.1f%%)", amount, percentage),
+		})
+	}
+
+	if result.OverkillDamage > 0 {
+		rows = append(rows, reportRow{
+			Label: "Overkill",
+			Value: fmt.Sprintf("%.1f (raw DPS %.1f)", result.OverkillDamage, result.RawDPS),
+		})
+	}
+
+	if result.WastedAttacks > 0 {
+		rows = append(rows, reportRow{
+			Label: "Wasted Attacks",
+			Value: fmt.Sprintf("%d (%.1f damage)", result.WastedAttacks, result.WastedDamage),
+		})
+	}
+
+	targets := make([]string, 0, len(result.TimeToKill))
+	for name := range result.TimeToKill {
+		targets = append(targets, name)
+	}
+	sort.Strings(targets)
+
+	for _, name := range targets {
+		value := "not killed"
+		if ttk := result.TimeToKill[name]; ttk >= 0 {
+			value = fmt.Sprintf("%.2fs", ttk.Seconds())
+		}
+		rows = append(rows, reportRow{Label: "Time to Kill: " + name, Value: value})
+	}
+
+	return rows
+}
+
+// finalStatRows formats SimulationResult.Stats in a stable order
+func finalStatRows(result sim.SimulationResult) []reportRow {
+	keys := make([]string, 0, len(result.Stats))
+	for key := range result.Stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := make([]reportRow, 0, len(keys))
+	for _, key := range keys {
+		value := fmt.Sprintf("%v", result.Stats[key])
+		if f, ok := result.Stats[key].(float64); ok {
+			value = fmt.Sprintf("%.2f", f)
+		}
+		rows = append(rows, reportRow{Label: key, Value: value})
+	}
+	return rows
+}
+
+// sortedDamageTypes returns the damage types present in a result in enum order
+func sortedDamageTypes(result sim.SimulationResult) []models.DamageType {
+	types := make([]models.DamageType, 0, len(result.DamageByType))
+	for dmgType := range result.DamageByType {
+		types = append(types, dmgType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #222; }
+h1 { border-bottom: 2px solid #ccc; padding-bottom: .3em; }
+h2 { margin-top: 2em; }
+img { max-width: 100%; border: 1px solid #ddd; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ddd; padding: .3em .8em; text-align: left; }
+th { background: #f4f4f4; }
+.charts { display: flex; flex-wrap: wrap; gap: 1em; }
+.charts img { width: 48%; min-width: 400px; }
+.muted { color: #777; }
+.error { color: #a00; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">Generated {{.Generated}}</p>
+
+<h2>Damage Over Time</h2>
+<img src="{{.ComparisonChart}}" alt="Build comparison chart">
+
+<h2>Summary</h2>
+<table>
+<tr><th>Build</th>{{range .SummaryHeaders}}<th>{{.}}</th>{{end}}</tr>
+{{range .Builds}}<tr><td>{{.Name}}</td>{{range .Summary}}<td>{{.Value}}</td>{{end}}</tr>
+{{end}}</table>
+
+{{range .Builds}}
+<h2>{{.Name}}</h2>
+<p><strong>Items:</strong> {{range $i, $item := .Items}}{{if $i}}, {{end}}{{$item}}{{else}}<span class="muted">none</span>{{end}}</p>
+<p><strong>Augments:</strong> {{range $i, $augment := .Augments}}{{if $i}}, {{end}}{{$augment}}{{else}}<span class="muted">none</span>{{end}}</p>
+<p><strong>Active Traits:</strong> {{range $i, $trait := .Traits}}{{if $i}}, {{end}}{{$trait}}{{else}}<span class="muted">none</span>{{end}}</p>
+{{if .Mechanics}}<p><strong>Set Mechanics:</strong> {{range $i, $mechanic := .Mechanics}}{{if $i}}, {{end}}{{$mechanic}}{{end}}</p>
+{{end}}<table>
+<tr><th>Breakdown</th><th>Value</th></tr>
+{{range .Details}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+<table>
+<tr><th>Final Stat</th><th>Value</th></tr>
+{{range .FinalStats}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+<div class="charts">
+{{if .TypeChart}}<img src="{{.TypeChart}}" alt="Damage by type for {{.Name}}">{{end}}
+{{if .DPSChart}}<img src="{{.DPSChart}}" alt="DPS for {{.Name}}">{{end}}
+</div>
+{{range .ChartErrors}}<p class="error">Chart unavailable: {{.}}</p>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
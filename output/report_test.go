@@ -0,0 +1,82 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"tft-sim/sim/units"
+	"time"
+)
+
+// runReportBuild simulates a 2 star unit against one dummy
+func runReportBuild(t *testing.T, name string, hp float64) ReportBuild {
+	t.Helper()
+	unit, exists := units.Get(name, 2)
+	if !exists {
+		t.Fatalf("unit %s not found", name)
+	}
+
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", hp, 50, 50)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 15 * time.Second
+	simulator.Config.Seed = 1
+	return ReportBuild{Name: name, Result: simulator.Run()}
+}
+
+func TestGenerateHTMLReport(t *testing.T) {
+	builds := []ReportBuild{
+		runReportBuild(t, "Lux", 500),
+		runReportBuild(t, "Ahri", 100000),
+	}
+
+	lux := summaryRows(builds[0].Result)
+	if casts := len(builds[0].Result.CastWindows); casts == 0 || builds[0].Result.AbilityCount != casts {
+		t.Errorf("Expected ability casts to match %d cast windows, got %d", casts, builds[0].Result.AbilityCount)
+	}
+	if got, want := lux[4].Value, strconv.Itoa(builds[0].Result.AbilityCount); got != want {
+		t.Errorf("Ability Casts cell = %q, want %q", got, want)
+	}
+	ttk := builds[0].Result.TimeToKill["Dummy"]
+	if ttk <= 0 {
+		t.Fatalf("Expected Lux to kill the dummy, got TTK %v", ttk)
+	}
+	if got, want := lux[5].Value, fmt.Sprintf("%.2fs", ttk.Seconds()); got != want {
+		t.Errorf("Time to Kill cell = %q, want %q", got, want)
+	}
+	if got := summaryRows(builds[1].Result)[5].Value; got != "not killed (0/1)" {
+		t.Errorf("Time to Kill cell for a survivor = %q, want %q", got, "not killed (0/1)")
+	}
+
+	filename := filepath.Join(t.TempDir(), "report.html")
+	if err := GenerateHTMLReport(builds, filename); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+
+	for _, header := range summaryHeaders {
+		if !strings.Contains(html, "<th>"+header+"</th>") {
+			t.Errorf("Summary table is missing %q", header)
+		}
+	}
+
+	// The report must open offline, so every resource is inlined
+	for _, match := range regexp.MustCompile(`(?:src|href)="([^"]*)"`).FindAllStringSubmatch(html, -1) {
+		if !strings.HasPrefix(match[1], "data:") && !strings.HasPrefix(match[1], "#") {
+			t.Errorf("Report references external resource %q", match[1])
+		}
+	}
+
+	if err := GenerateHTMLReport(nil, filename); err == nil {
+		t.Error("Expected error without builds")
+	}
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"fmt"
+	"tft-sim/models"
+	"tft-sim/sim/analysis"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// GenerateStatWeightChart plots the gain from each stat's step as a bar with
+// its 95% confidence interval
+func GenerateStatWeightChart(result analysis.StatWeightResult, filename string, opts ChartOptions) error {
+	p, err := newStatWeightPlot(result, opts.theme())
+	if err != nil {
+		return err
+	}
+
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 6*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newStatWeightPlot builds the bar chart used by GenerateStatWeightChart
+func newStatWeightPlot(result analysis.StatWeightResult, theme Theme) (*plot.Plot, error) {
+	if len(result.Weights) == 0 {
+		return nil, fmt.Errorf("no stat weights to plot")
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("Stat weights: %s", result.Build.Name)
+	p.Y.Label.Text = fmt.Sprintf("%s gained", result.Metric)
+
+	gains := make(plotter.Values, len(result.Weights))
+	errs := make(curveErrors, len(result.Weights))
+	labels := make(labelTicks, len(result.Weights))
+	for i, w := range result.Weights {
+		low, _ := w.Gain.CI95()
+		gains[i] = w.Gain.Mean
+		errs[i] = curveError{x: float64(i), y: w.Gain.Mean, err: w.Gain.Mean - low}
+		labels[i] = statStepLabel(w.Stat, w.Step)
+	}
+
+	bars, err := plotter.NewBarChart(gains, vg.Points(30))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stat weight bars: %w", err)
+	}
+	bars.Color = theme.Color(0)
+	bars.LineStyle.Color = theme.Foreground
+
+	errBars, err := plotter.NewYErrorBars(errs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stat weight error bars: %w", err)
+	}
+	errBars.Color = theme.Foreground
+
+	p.Add(bars, errBars)
+	p.X.Tick.Marker = labels
+
+	return p, nil
+}
+
+// fractionStats are flat stats stored as fractions, labelled as percentages
+var fractionStats = map[models.StatType]bool{
+	models.StatAbilityPower:    true,
+	models.StatCritChance:      true,
+	models.StatCritDamage:      true,
+	models.StatVamp:            true,
+	models.StatDamageReduction: true,
+	models.StatDamageAmp:       true,
+}
+
+// statStepLabel names a stat step, e.g. "+10% Attack Speed" or "+1 Mana Regen"
+func statStepLabel(stat models.StatType, step float64) string {
+	if stat.Kind() == models.StatKindPercent || fractionStats[stat] {
+		return fmt.Sprintf("%+.4g%% %s", step*100, stat)
+	}
+	return fmt.Sprintf("%+.4g %s", step, stat)
+}
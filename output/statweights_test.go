@@ -0,0 +1,44 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim/analysis"
+)
+
+func TestGenerateStatWeightChart(t *testing.T) {
+	result := analysis.StatWeightResult{
+		Build: analysis.Build{Name: "IE"},
+		Weights: []analysis.StatWeight{
+			{Stat: models.StatAttackSpeed, Step: 0.1, Gain: analysis.Summary{Mean: 20, StdErr: 1}},
+			{Stat: models.StatManaRegen, Step: 1},
+		},
+	}
+
+	filename := filepath.Join(t.TempDir(), "weights.svg")
+	if err := GenerateStatWeightChart(result, filename, ChartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Error("Stat weight chart was not written")
+	}
+
+	if err := GenerateStatWeightChart(analysis.StatWeightResult{}, filename, ChartOptions{}); err == nil {
+		t.Error("Expected error for an empty result")
+	}
+}
+
+func TestStatStepLabel(t *testing.T) {
+	cases := map[string]string{
+		statStepLabel(models.StatAttackSpeed, 0.1):  "+10% Attack Speed",
+		statStepLabel(models.StatAbilityPower, 0.1): "+10% Ability Power",
+		statStepLabel(models.StatManaRegen, 2):      "+2 Mana Regen",
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
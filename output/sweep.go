@@ -0,0 +1,229 @@
+package output
+
+import (
+	"fmt"
+	"image/color"
+	"tft-sim/sim/sweep"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// maxHeatmapLabels is the largest heatmap that gets its values printed in each cell
+const maxHeatmapLabels = 100
+
+// GenerateSweepChart plots the mean of a metric against one swept dimension,
+// with one line per value of the series dimension (sweep.DimNone for a
+// single line). Repeated seeds are averaged.
+func GenerateSweepChart(table sweep.Table, metric sweep.Metric, x, series sweep.Dimension, filename string, opts ChartOptions) error {
+	p, err := newSweepPlot(table, metric, x, series, opts.theme())
+	if err != nil {
+		return err
+	}
+
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 6*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newSweepPlot builds the line plot used by GenerateSweepChart
+func newSweepPlot(table sweep.Table, metric sweep.Metric, x, series sweep.Dimension, theme Theme) (*plot.Plot, error) {
+	if len(table.Rows) == 0 {
+		return nil, fmt.Errorf("no sweep rows to plot")
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("%s by %s", metric, x)
+	p.X.Label.Text = x.String()
+	p.Y.Label.Text = metric.String()
+	p.Legend.Top = true
+
+	// Cells are sorted by series first, so each series is a contiguous run
+	cells := table.Aggregate(metric, series, x)
+	for start, line := 0, 0; start < len(cells); line++ {
+		end := start
+		for end < len(cells) && cells[end].Values[0] == cells[start].Values[0] {
+			end++
+		}
+
+		// Cells where no run killed the target have no time to plot
+		pts := make(plotter.XYs, 0, end-start)
+		for _, cell := range cells[start:end] {
+			if cell.Reached > 0 {
+				pts = append(pts, plotter.XY{X: cell.Values[1], Y: cell.Mean})
+			}
+		}
+		if len(pts) == 0 {
+			start = end
+			continue
+		}
+
+		l, s, err := plotter.NewLinePoints(pts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create line for %s: %w", cells[start].Labels[0], err)
+		}
+		color := theme.Color(line)
+		l.Color = color
+		l.Width = vg.Points(1.5)
+		s.GlyphStyle.Color = color
+		s.GlyphStyle.Radius = vg.Points(2.5)
+		s.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[line%len(plotutil.DefaultGlyphShapes)]
+
+		p.Add(l, s)
+		if series != sweep.DimNone {
+			p.Legend.Add(cells[start].Labels[0], l)
+		}
+		start = end
+	}
+
+	// Categorical values are indexes, label them instead
+	if x.Categorical() {
+		xLabels := make(labelTicks, 0)
+		for _, cell := range table.Aggregate(metric, x) {
+			xLabels = append(xLabels, cell.Labels[0])
+		}
+		p.X.Tick.Marker = xLabels
+	}
+
+	return p, nil
+}
+
+// GenerateSweepHeatmap plots the mean of a metric over two swept dimensions.
+// Repeated seeds and any other swept dimensions are averaged.
+func GenerateSweepHeatmap(table sweep.Table, metric sweep.Metric, x, y sweep.Dimension, filename string, opts ChartOptions) error {
+	p, err := newSweepHeatmapPlot(table, metric, x, y)
+	if err != nil {
+		return err
+	}
+
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 8*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newSweepHeatmapPlot builds the heatmap used by GenerateSweepHeatmap. Cells
+// are laid out on an index grid so uneven and categorical steps get equal
+// space; the axis ticks carry the real values.
+func newSweepHeatmapPlot(table sweep.Table, metric sweep.Metric, x, y sweep.Dimension) (*plot.Plot, error) {
+	if len(table.Rows) == 0 {
+		return nil, fmt.Errorf("no sweep rows to plot")
+	}
+
+	cells := table.Aggregate(metric, x, y)
+	grid := newSweepGrid(cells)
+
+	cols, rows := grid.Dims()
+	if cols*rows != len(cells) {
+		return nil, fmt.Errorf("sweep over %s and %s does not form a complete grid", x, y)
+	}
+
+	heatmap := plotter.NewHeatMap(grid, heatmapPalette())
+	heatmap.NaN = color.Gray{Y: 0xdd} // No run killed the target
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("%s by %s and %s", metric, x, y)
+	p.X.Label.Text = x.String()
+	p.Y.Label.Text = y.String()
+	p.X.Tick.Marker = labelTicks(grid.xLabels)
+	p.Y.Tick.Marker = labelTicks(grid.yLabels)
+	p.Add(heatmap)
+
+	// Print values in each cell when they fit
+	if len(cells) <= maxHeatmapLabels {
+		labels := plotter.XYLabels{
+			XYs:    make(plotter.XYs, len(cells)),
+			Labels: make([]string, len(cells)),
+		}
+		for i, cell := range cells {
+			labels.XYs[i] = plotter.XY{X: float64(i / rows), Y: float64(i % rows)}
+			labels.Labels[i] = fmt.Sprintf("%.4g", cell.Mean)
+			if cell.Reached == 0 {
+				labels.Labels[i] = "-"
+			}
+		}
+		l, err := plotter.NewLabels(labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create heatmap labels: %w", err)
+		}
+		for i := range l.TextStyle {
+			l.TextStyle[i].XAlign = -0.5
+			l.TextStyle[i].YAlign = -0.5
+		}
+		p.Add(l)
+	}
+
+	return p, nil
+}
+
+// heatmapPalette samples the lighter part of the Kindlmann color map so the
+// printed values stay readable on the lowest cells
+func heatmapPalette() palette.Palette {
+	colors := moreland.Kindlmann()
+	colors.SetMin(0)
+	colors.SetMax(1)
+
+	const steps = 255
+	p := make(heatmapColors, steps)
+	for i := range p {
+		c, err := colors.At(0.3 + 0.65*float64(i)/(steps-1))
+		if err != nil {
+			panic(err) // Unreachable, the value is within [0, 1]
+		}
+		p[i] = c
+	}
+	return p
+}
+
+type heatmapColors []color.Color
+
+func (p heatmapColors) Colors() []color.Color { return p }
+
+// sweepGrid adapts aggregated cells, sorted by x then y, to plotter.GridXYZ.
+// Labels are collected in first-seen order, which is sorted for a complete grid.
+type sweepGrid struct {
+	xLabels []string
+	yLabels []string
+	z       []float64 // Column-major, matching the cell order
+}
+
+func newSweepGrid(cells []sweep.Cell) sweepGrid {
+	var g sweepGrid
+	xSeen := make(map[float64]bool)
+	ySeen := make(map[float64]bool)
+	for _, cell := range cells {
+		if !xSeen[cell.Values[0]] {
+			xSeen[cell.Values[0]] = true
+			g.xLabels = append(g.xLabels, cell.Labels[0])
+		}
+		if !ySeen[cell.Values[1]] {
+			ySeen[cell.Values[1]] = true
+			g.yLabels = append(g.yLabels, cell.Labels[1])
+		}
+		g.z = append(g.z, cell.Mean)
+	}
+	return g
+}
+
+func (g sweepGrid) Dims() (c, r int)   { return len(g.xLabels), len(g.yLabels) }
+func (g sweepGrid) Z(c, r int) float64 { return g.z[c*len(g.yLabels)+r] }
+func (g sweepGrid) X(c int) float64    { return float64(c) }
+func (g sweepGrid) Y(r int) float64    { return float64(r) }
+
+// labelTicks places one labelled tick at each integer position
+type labelTicks []string
+
+func (t labelTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := make([]plot.Tick, len(t))
+	for i, label := range t {
+		ticks[i] = plot.Tick{Value: float64(i), Label: label}
+	}
+	return ticks
+}
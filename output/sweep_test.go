@@ -0,0 +1,98 @@
+package output
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+// newTestSweepTable builds a 3 armor x 2 item set table with two seeds per
+// point. Red never kills the target at 200 armor.
+func newTestSweepTable() sweep.Table {
+	var table sweep.Table
+	itemSets := [][]string{{"IE"}, {"Red"}}
+	for _, armor := range []float64{0, 100, 200} {
+		for set, itemNames := range itemSets {
+			for seed := int64(1); seed <= 2; seed++ {
+				row := sweep.Row{
+					Point:      sweep.Point{Armor: armor, Items: itemNames, ItemSet: set, Seed: seed},
+					DPS:        1000/(1+armor/100) + float64(set*100+int(seed)),
+					TimeToKill: time.Duration(5+armor/50) * time.Second,
+				}
+				if armor == 200 && set == 1 {
+					row.TimeToKill = -1
+				}
+				table.Rows = append(table.Rows, row)
+			}
+		}
+	}
+	return table
+}
+
+func TestGenerateSweepCharts(t *testing.T) {
+	table := newTestSweepTable()
+	dir := t.TempDir()
+
+	charts := map[string]func(string) error{
+		"line.png": func(f string) error {
+			return GenerateSweepChart(table, sweep.MetricDPS, sweep.DimArmor, sweep.DimItems, f, ChartOptions{})
+		},
+		"categorical.svg": func(f string) error {
+			return GenerateSweepChart(table, sweep.MetricDPS, sweep.DimItems, sweep.DimNone, f, ChartOptions{})
+		},
+		"heatmap.png": func(f string) error {
+			return GenerateSweepHeatmap(table, sweep.MetricDPS, sweep.DimArmor, sweep.DimItems, f, ChartOptions{})
+		},
+		"ttk_line.png": func(f string) error {
+			return GenerateSweepChart(table, sweep.MetricTimeToKill, sweep.DimArmor, sweep.DimItems, f, ChartOptions{})
+		},
+		"ttk_heatmap.png": func(f string) error {
+			return GenerateSweepHeatmap(table, sweep.MetricTimeToKill, sweep.DimArmor, sweep.DimItems, f, ChartOptions{})
+		},
+	}
+
+	for name, generate := range charts {
+		filename := filepath.Join(dir, name)
+		if err := generate(filename); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+			t.Errorf("%s was not written", name)
+		}
+	}
+}
+
+func TestSweepHeatmapGrid(t *testing.T) {
+	cells := newTestSweepTable().Aggregate(sweep.MetricDPS, sweep.DimArmor, sweep.DimItems)
+	grid := newSweepGrid(cells)
+
+	if c, r := grid.Dims(); c != 3 || r != 2 {
+		t.Fatalf("Expected a 3x2 grid, got %dx%d", c, r)
+	}
+	// Armor 100 with Red averages seeds 1 and 2
+	if got := grid.Z(1, 1); got != 500+100+1.5 {
+		t.Errorf("Z(1, 1) = %.2f, want 601.5", got)
+	}
+	if grid.yLabels[1] != "Red" || grid.xLabels[2] != "200" {
+		t.Errorf("Unexpected labels %v %v", grid.xLabels, grid.yLabels)
+	}
+
+	// A cell that never killed the target has no time instead of the -1 sentinel
+	ttk := newSweepGrid(newTestSweepTable().Aggregate(sweep.MetricTimeToKill, sweep.DimArmor, sweep.DimItems))
+	if got := ttk.Z(2, 1); !math.IsNaN(got) {
+		t.Errorf("Z(2, 1) = %.2f for a target that survived, want NaN", got)
+	}
+	if got := ttk.Z(2, 0); got != 9 {
+		t.Errorf("Z(2, 0) = %.2f, want 9", got)
+	}
+
+	// Dropping a point leaves a hole in the grid
+	table := newTestSweepTable()
+	table.Rows = table.Rows[2:]
+	if err := GenerateSweepHeatmap(table, sweep.MetricDPS, sweep.DimArmor, sweep.DimItems, filepath.Join(t.TempDir(), "h.png"), ChartOptions{}); err == nil {
+		t.Error("Expected error for an incomplete grid")
+	}
+}
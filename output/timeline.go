@@ -0,0 +1,233 @@
+package output
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"tft-sim/models"
+	"tft-sim/sim"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// castShadeColor is the translucent fill used for ability cast windows
+var castShadeColor = color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x40}
+
+// GenerateStatTimelineChart creates a multi-panel chart of the sampled stat
+// timeline, one panel per stat plus mana, item stacks and buff stacks when
+// they were recorded. Ability cast windows are shaded in every panel.
+func GenerateStatTimelineChart(results sim.SimulationResult, filename string, opts ChartOptions) error {
+	if len(results.Timeline) == 0 {
+		return fmt.Errorf("no timeline samples available (is SimulationConfig.Timeline.Interval set?)")
+	}
+
+	theme := opts.theme()
+	plots, err := newTimelinePlots(results, theme)
+	if err != nil {
+		return err
+	}
+
+	title := "Stat Timeline"
+	if opts.Title != "" {
+		title = opts.Title
+	}
+	plots[0].Title.Text = title
+	for _, p := range plots {
+		applyTheme(p, theme)
+	}
+
+	drawFn := func(c draw.Canvas) {
+		if theme.Background != nil {
+			c.SetColor(theme.Background)
+			c.Fill(c.Rectangle.Path())
+		}
+
+		grid := make([][]*plot.Plot, len(plots))
+		for i, p := range plots {
+			grid[i] = []*plot.Plot{p}
+		}
+		tiles := draw.Tiles{
+			Rows:      len(plots),
+			Cols:      1,
+			PadTop:    vg.Points(4),
+			PadBottom: vg.Points(4),
+			PadLeft:   vg.Points(4),
+			PadRight:  vg.Points(4),
+			PadY:      vg.Points(8),
+		}
+		canvases := plot.Align(grid, tiles, c)
+		for i, p := range plots {
+			p.Draw(canvases[i][0])
+		}
+	}
+
+	height := vg.Length(len(plots)) * 2.5 * vg.Inch
+	if err := saveDrawing(filename, opts, 10*vg.Inch, height, drawFn); err != nil {
+		return fmt.Errorf("failed to save timeline chart: %w", err)
+	}
+
+	return nil
+}
+
+// newTimelinePlots builds one panel per recorded timeline series
+func newTimelinePlots(results sim.SimulationResult, theme Theme) ([]*plot.Plot, error) {
+	samples := results.Timeline
+	endTime := samples[len(samples)-1].Timestamp.Seconds()
+	var plots []*plot.Plot
+
+	newPanel := func(label string) *plot.Plot {
+		p := plot.New()
+		p.X.Label.Text = "Time (seconds)"
+		p.Y.Label.Text = label
+		p.X.Min = 0
+		p.X.Max = endTime
+		p.X.Tick.Marker = fiveUnitTicks{}
+		p.Legend.Top = true
+		p.Add(castWindowShading{windows: results.CastWindows, color: castShadeColor})
+		return p
+	}
+
+	addLine := func(p *plot.Plot, name string, index int, value func(sim.StatSample) float64) error {
+		pts := make(plotter.XYs, len(samples))
+		for i, sample := range samples {
+			pts[i].X = sample.Timestamp.Seconds()
+			pts[i].Y = value(sample)
+		}
+
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return fmt.Errorf("failed to create %s line: %w", name, err)
+		}
+		line.Color = theme.Color(index)
+		line.Width = vg.Points(1.5)
+		line.StepStyle = plotter.PostStep
+		p.Add(line)
+		p.Legend.Add(name, line)
+		return nil
+	}
+
+	// One panel per sampled stat, in StatType order
+	stats := make([]models.StatType, 0, len(samples[0].Stats))
+	for stat := range samples[0].Stats {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i] < stats[j] })
+	for i, stat := range stats {
+		stat := stat
+		p := newPanel(stat.String())
+		if err := addLine(p, stat.String(), i, func(sample sim.StatSample) float64 {
+			return sample.Stats[stat]
+		}); err != nil {
+			return nil, err
+		}
+		plots = append(plots, p)
+	}
+
+	if hasMana(samples) {
+		p := newPanel("Mana")
+		if err := addLine(p, "Current Mana", 0, func(sample sim.StatSample) float64 {
+			return sample.Mana
+		}); err != nil {
+			return nil, err
+		}
+		if err := addLine(p, "Max Mana", 1, func(sample sim.StatSample) float64 {
+			return sample.MaxMana
+		}); err != nil {
+			return nil, err
+		}
+		plots = append(plots, p)
+	}
+
+	if names := seriesNames(samples, func(s sim.StatSample) map[string]int { return s.ItemStacks }); len(names) > 0 {
+		p := newPanel("Item Stacks")
+		for i, name := range names {
+			name := name
+			if err := addLine(p, name, i, func(sample sim.StatSample) float64 {
+				return float64(sample.ItemStacks[name])
+			}); err != nil {
+				return nil, err
+			}
+		}
+		plots = append(plots, p)
+	}
+
+	if names := seriesNames(samples, func(s sim.StatSample) map[string]int { return s.Buffs }); len(names) > 0 {
+		p := newPanel("Buff Stacks")
+		for i, name := range names {
+			name := name
+			if err := addLine(p, name, i, func(sample sim.StatSample) float64 {
+				return float64(sample.Buffs[name])
+			}); err != nil {
+				return nil, err
+			}
+		}
+		plots = append(plots, p)
+	}
+
+	if len(plots) == 0 {
+		return nil, fmt.Errorf("timeline samples contain no series to plot")
+	}
+
+	return plots, nil
+}
+
+// hasMana reports whether mana was sampled (any non-zero value)
+func hasMana(samples []sim.StatSample) bool {
+	for _, sample := range samples {
+		if sample.Mana != 0 || sample.MaxMana != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesNames collects every key seen across samples in sorted order
+func seriesNames(samples []sim.StatSample, values func(sim.StatSample) map[string]int) []string {
+	seen := make(map[string]bool)
+	for _, sample := range samples {
+		for name := range values(sample) {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// castWindowShading is a plotter that shades ability cast windows across
+// the full height of the data area
+type castWindowShading struct {
+	windows []sim.CastWindow
+	color   color.Color
+}
+
+func (s castWindowShading) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, _ := p.Transforms(&c)
+	for _, window := range s.windows {
+		x0 := trX(window.Start.Seconds())
+		x1 := trX(window.End.Seconds())
+		if x1 < c.Min.X || x0 > c.Max.X {
+			continue
+		}
+		if x0 < c.Min.X {
+			x0 = c.Min.X
+		}
+		if x1 > c.Max.X {
+			x1 = c.Max.X
+		}
+
+		c.FillPolygon(s.color, []vg.Point{
+			{X: x0, Y: c.Min.Y},
+			{X: x1, Y: c.Min.Y},
+			{X: x1, Y: c.Max.Y},
+			{X: x0, Y: c.Max.Y},
+		})
+	}
+}
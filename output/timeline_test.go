@@ -0,0 +1,96 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// timelineResult is a short fight with two casts and every series sampled
+func timelineResult() sim.SimulationResult {
+	var samples []sim.StatSample
+	for ms := 0; ms <= 5000; ms += 100 {
+		samples = append(samples, sim.StatSample{
+			Timestamp:  time.Duration(ms) * time.Millisecond,
+			Stats:      map[models.StatType]float64{models.StatAttackSpeed: 1 + float64(ms)/10000},
+			Mana:       float64(ms%2000) / 20,
+			MaxMana:    100,
+			ItemStacks: map[string]int{"Guinsoos_1": ms / 1000},
+			Buffs:      map[string]int{"Frenzy": ms / 500},
+		})
+	}
+	return sim.SimulationResult{
+		Timeline: samples,
+		CastWindows: []sim.CastWindow{
+			{Ability: "Nuke", Start: 2 * time.Second, End: 2500 * time.Millisecond},
+			{Ability: "Nuke", Start: 4 * time.Second, End: 4500 * time.Millisecond},
+		},
+	}
+}
+
+func TestGenerateStatTimelineChart(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "timeline.png")
+	if err := GenerateStatTimelineChart(timelineResult(), filename, ChartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Error("Timeline chart was not written")
+	}
+
+	if err := GenerateStatTimelineChart(sim.SimulationResult{}, filename, ChartOptions{}); err == nil {
+		t.Error("Expected error without timeline samples")
+	}
+}
+
+func TestTimelinePanels(t *testing.T) {
+	plots, err := newTimelinePlots(timelineResult(), LightTheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{models.StatAttackSpeed.String(), "Mana", "Item Stacks", "Buff Stacks"}
+	if len(plots) != len(want) {
+		t.Fatalf("Expected %d panels, got %d", len(want), len(plots))
+	}
+	for i, label := range want {
+		if plots[i].Y.Label.Text != label {
+			t.Errorf("Panel %d is %q, want %q", i, plots[i].Y.Label.Text, label)
+		}
+	}
+}
+
+func TestCastWindowShadingIsDrawn(t *testing.T) {
+	result := timelineResult()
+	plots, err := newTimelinePlots(result, LightTheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &recorder.Canvas{}
+	c := draw.Canvas{Canvas: rec, Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Inch, Y: 3 * vg.Inch}}}
+	plots[0].Draw(c)
+
+	// Count fills made in the shading color, one per cast window
+	shaded := 0
+	shading := false
+	for _, action := range rec.Actions {
+		switch a := action.(type) {
+		case *recorder.SetColor:
+			shading = a.Color == castShadeColor
+		case *recorder.Fill:
+			if shading {
+				shaded++
+			}
+		}
+	}
+	if shaded != len(result.CastWindows) {
+		t.Errorf("Expected %d shaded cast windows, got %d", len(result.CastWindows), shaded)
+	}
+}
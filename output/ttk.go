@@ -0,0 +1,78 @@
+package output
+
+import (
+	"fmt"
+	"tft-sim/sim/analysis"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// GenerateTTKChart plots each build's mean time to kill against target HP
+// with 95% error bars. Builds that front-load their damage start low, builds
+// that ramp up flatten out at larger HP pools. HP pools that some seeds
+// failed to kill within the fight are left out.
+func GenerateTTKChart(curves []analysis.TTKCurve, filename string, opts ChartOptions) error {
+	p, err := newTTKPlot(curves, opts.theme())
+	if err != nil {
+		return err
+	}
+
+	if err := saveChart(p, filename, opts, 10*vg.Inch, 6*vg.Inch); err != nil {
+		return fmt.Errorf("failed to save chart: %w", err)
+	}
+
+	return nil
+}
+
+// newTTKPlot builds the plot used by GenerateTTKChart
+func newTTKPlot(curves []analysis.TTKCurve, theme Theme) (*plot.Plot, error) {
+	if len(curves) == 0 {
+		return nil, fmt.Errorf("no TTK curves to plot")
+	}
+
+	p := plot.New()
+	p.Title.Text = "Time to Kill by Target HP"
+	p.X.Label.Text = "Target HP"
+	p.Y.Label.Text = "Time to Kill (seconds)"
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	for i, curve := range curves {
+		var pts curveErrors
+		for _, point := range curve.Points {
+			if !point.Complete() {
+				continue
+			}
+			low, _ := point.Seconds.CI95()
+			pts = append(pts, curveError{x: point.HP, y: point.Seconds.Mean, err: point.Seconds.Mean - low})
+		}
+		if len(pts) == 0 {
+			continue // Never killed even the smallest target
+		}
+
+		line, scatter, err := plotter.NewLinePoints(pts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create line for %s: %w", curve.Build.Name, err)
+		}
+		bars, err := plotter.NewYErrorBars(pts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create error bars for %s: %w", curve.Build.Name, err)
+		}
+
+		color := theme.Color(i)
+		line.Color = color
+		line.Width = vg.Points(1.5)
+		scatter.GlyphStyle.Color = color
+		scatter.GlyphStyle.Radius = vg.Points(2)
+		scatter.GlyphStyle.Shape = plotutil.DefaultGlyphShapes[i%len(plotutil.DefaultGlyphShapes)]
+		bars.Color = color
+
+		p.Add(line, scatter, bars)
+		p.Legend.Add(curve.Build.Name, line)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"tft-sim/sim/analysis"
+)
+
+func TestGenerateTTKChart(t *testing.T) {
+	curves := []analysis.TTKCurve{
+		{
+			Build: analysis.Build{Name: "Front-loaded"},
+			Points: []analysis.TTKPoint{
+				{HP: 500, Seconds: analysis.Summary{Mean: 2, StdErr: 0.1}, Reached: 2, Runs: 2},
+				{HP: 1000, Seconds: analysis.Summary{Mean: 5}, Reached: 1, Runs: 2}, // Left out
+			},
+		},
+		{Build: analysis.Build{Name: "Never kills"}, Points: []analysis.TTKPoint{{HP: 500, Runs: 2}}},
+	}
+
+	filename := filepath.Join(t.TempDir(), "ttk.svg")
+	if err := GenerateTTKChart(curves, filename, ChartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Error("TTK chart was not written")
+	}
+
+	if err := GenerateTTKChart(nil, filename, ChartOptions{}); err == nil {
+		t.Error("Expected error without curves")
+	}
+}
@@ -0,0 +1,451 @@
+package abilities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tft-sim/models"
+	"unicode"
+)
+
+// tokenKind classifies a script token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenEnd           // End of a statement, a newline or semicolon
+	tokenNumber
+	tokenIdent
+	tokenString
+	tokenSymbol // One of + - % [ ] / ,
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of script"
+	case tokenEnd:
+		return "end of line"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits a script into tokens. # starts a comment running to the end
+// of the line.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n' || r == ';':
+			tokens = append(tokens, token{kind: tokenEnd, text: string(r), line: line})
+			if r == '\n' {
+				line++
+			}
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' && runes[j] != '\n' {
+				j++
+			}
+			if j == len(runes) || runes[j] != '"' {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[i+1 : j]), line: line})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[i:j]), line: line})
+			i = j
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[i:j]), line: line})
+			i = j
+		case strings.ContainsRune("+-%[]/,", r):
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r), line: line})
+			i++
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+var damageTypes = map[string]models.DamageType{
+	"physical": models.DamageTypePhysical,
+	"magic":    models.DamageTypeMagic,
+	"true":     models.DamageTypeTrue,
+}
+
+var selectors = map[string]Selector{
+	"target":  SelectTarget,
+	"all":     SelectAll,
+	"lowest":  SelectLowestHP,
+	"highest": SelectHighestHP,
+}
+
+var scalings = map[string]Scaling{
+	"ad": ScalingAD,
+	"ap": ScalingAP,
+	"hp": ScalingHP,
+}
+
+// buffStat is a stat buffs accept and whether its modifier is written as a
+// percentage
+type buffStat struct {
+	stat    models.StatType
+	percent bool
+}
+
+// buffStats maps the stat names buffs accept to stats. AD and AS bonuses are
+// percent of base and crit, amp, damage reduction and omnivamp are
+// fractions, so they are written with %; the rest are written in points.
+var buffStats = map[string]buffStat{
+	"hp":        {models.StatHealth, false},
+	"armor":     {models.StatArmor, false},
+	"mr":        {models.StatMagicResist, false},
+	"ad":        {models.StatAttackDamage, true},
+	"ap":        {models.StatAbilityPower, false},
+	"as":        {models.StatAttackSpeed, true},
+	"crit":      {models.StatCritChance, true},
+	"critdmg":   {models.StatCritDamage, true},
+	"manaregen": {models.StatManaRegen, false},
+	"omnivamp":  {models.StatVamp, true},
+	"dr":        {models.StatDamageReduction, true},
+	"amp":       {models.StatDamageAmp, true},
+}
+
+// keyword normalizes an identifier for matching keywords and stat names
+func keyword(s string) string {
+	return strings.ToLower(s)
+}
+
+// parser reads a script's tokens into effects
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a script into its effects
+func Parse(src string) (Script, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	var script Script
+	for {
+		for p.peek().kind == tokenEnd {
+			p.next()
+		}
+		if p.peek().kind == tokenEOF {
+			return script, nil
+		}
+
+		effect, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		script = append(script, effect)
+
+		if t := p.next(); t.kind != tokenEnd && t.kind != tokenEOF {
+			return nil, p.errorf(t, "expected end of line, got %s", t)
+		}
+	}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", t.line, fmt.Sprintf(format, args...))
+}
+
+// accept consumes the next token if it is the given keyword or symbol
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokenIdent || t.kind == tokenSymbol) && keyword(t.text) == text {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.errorf(p.peek(), "expected %q, got %s", text, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) statement() (Effect, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return Effect{}, p.errorf(t, "expected an effect, got %s", t)
+	}
+
+	switch keyword(t.text) {
+	case "damage":
+		return p.damage()
+	case "heal":
+		amount, err := p.amount()
+		return Effect{Kind: EffectHeal, Amount: amount}, err
+	case "shield":
+		return p.shield()
+	case "stun":
+		return p.stun()
+	case "buff":
+		return p.buff()
+	case "call":
+		name := p.next()
+		if name.kind != tokenString {
+			return Effect{}, p.errorf(name, "expected a quoted function name, got %s", name)
+		}
+		return Effect{Kind: EffectCall, Name: name.text}, nil
+	}
+	return Effect{}, p.errorf(t, "unknown effect %s", t)
+}
+
+// damage := "damage" amount type ["to" selector]
+func (p *parser) damage() (Effect, error) {
+	amount, err := p.amount()
+	if err != nil {
+		return Effect{}, err
+	}
+
+	t := p.next()
+	damageType, ok := damageTypes[keyword(t.text)]
+	if t.kind != tokenIdent || !ok {
+		return Effect{}, p.errorf(t, "expected physical, magic or true damage, got %s", t)
+	}
+
+	effect := Effect{Kind: EffectDamage, Amount: amount, DamageType: damageType}
+	if p.accept("to") {
+		if effect.Selector, err = p.selector(); err != nil {
+			return Effect{}, err
+		}
+	}
+	return effect, nil
+}
+
+// shield := "shield" amount "for" duration
+func (p *parser) shield() (Effect, error) {
+	amount, err := p.amount()
+	if err != nil {
+		return Effect{}, err
+	}
+	if err := p.expect("for"); err != nil {
+		return Effect{}, err
+	}
+	duration, err := p.duration()
+	return Effect{Kind: EffectShield, Amount: amount, Duration: duration}, err
+}
+
+// stun := "stun" [selector] "for" duration
+func (p *parser) stun() (Effect, error) {
+	effect := Effect{Kind: EffectStun}
+	if !p.accept("for") {
+		var err error
+		if effect.Selector, err = p.selector(); err != nil {
+			return Effect{}, err
+		}
+		if err := p.expect("for"); err != nil {
+			return Effect{}, err
+		}
+	}
+	var err error
+	effect.Duration, err = p.duration()
+	return effect, err
+}
+
+// buff := "buff" string "for" duration "with" modifier {"," modifier}
+// modifier := ("+" | "-") value ["%"] stat
+//
+// Whether a modifier takes % depends on the stat, see buffStats.
+func (p *parser) buff() (Effect, error) {
+	name := p.next()
+	if name.kind != tokenString {
+		return Effect{}, p.errorf(name, "expected a quoted buff name, got %s", name)
+	}
+	if err := p.expect("for"); err != nil {
+		return Effect{}, err
+	}
+	duration, err := p.duration()
+	if err != nil {
+		return Effect{}, err
+	}
+	if err := p.expect("with"); err != nil {
+		return Effect{}, err
+	}
+
+	effect := Effect{Kind: EffectBuff, Name: name.text, Duration: duration, Stats: make(map[models.StatType]Value)}
+	for {
+		sign := 1.0
+		if p.accept("-") {
+			sign = -1
+		} else if err := p.expect("+"); err != nil {
+			return Effect{}, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return Effect{}, err
+		}
+		percent := p.accept("%")
+
+		t := p.next()
+		bs, ok := buffStats[keyword(t.text)]
+		if t.kind != tokenIdent || !ok {
+			return Effect{}, p.errorf(t, "unknown buff stat %s", t)
+		}
+		if percent != bs.percent {
+			if bs.percent {
+				return Effect{}, p.errorf(t, "%s bonus must be a percentage", t)
+			}
+			return Effect{}, p.errorf(t, "%s bonus must be in points, not a percentage", t)
+		}
+		stat := bs.stat
+
+		// AP is written in points and stored as a fraction like percentages
+		scale := sign
+		if percent || stat == models.StatAbilityPower {
+			scale /= 100
+		}
+		scaled := make(Value, len(value))
+		for i, v := range value {
+			scaled[i] = v * scale
+		}
+		effect.Stats[stat] = scaled
+
+		if !p.accept(",") {
+			return effect, nil
+		}
+	}
+}
+
+func (p *parser) selector() (Selector, error) {
+	t := p.next()
+	selector, ok := selectors[keyword(t.text)]
+	if t.kind != tokenIdent || !ok {
+		return 0, p.errorf(t, "expected target, all, lowest or highest, got %s", t)
+	}
+	return selector, nil
+}
+
+// amount := term {"+" term}
+// term := value ["%" ("AD" | "AP" | "HP")]
+func (p *parser) amount() (Amount, error) {
+	var amount Amount
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		term := Term{Value: value}
+		if p.accept("%") {
+			t := p.next()
+			scaling, ok := scalings[keyword(t.text)]
+			if t.kind != tokenIdent || !ok {
+				return nil, p.errorf(t, "expected AD, AP or HP after %%, got %s", t)
+			}
+			term.Scaling = scaling
+			for i := range term.Value {
+				term.Value[i] /= 100
+			}
+		}
+		amount = append(amount, term)
+
+		if !p.accept("+") {
+			return amount, nil
+		}
+	}
+}
+
+// duration := value ("s" | "ms"), returned in seconds
+func (p *parser) duration() (Value, error) {
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.accept("s"):
+	case p.accept("ms"):
+		for i := range value {
+			value[i] /= 1000
+		}
+	default:
+		return nil, p.errorf(p.peek(), "expected a duration unit s or ms, got %s", p.peek())
+	}
+	return value, nil
+}
+
+// value := number | "[" number {"/" number} "]"
+func (p *parser) value() (Value, error) {
+	if !p.accept("[") {
+		n, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		return Value{n}, nil
+	}
+
+	var value Value
+	for {
+		n, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		value = append(value, n)
+		if p.accept("]") {
+			return value, nil
+		}
+		if err := p.expect("/"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) number() (float64, error) {
+	t := p.next()
+	if t.kind != tokenNumber {
+		return 0, p.errorf(t, "expected a number, got %s", t)
+	}
+	n, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return 0, p.errorf(t, "invalid number %s", t)
+	}
+	return n, nil
+}
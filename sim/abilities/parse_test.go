@@ -0,0 +1,106 @@
+package abilities
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"tft-sim/models"
+)
+
+func TestParseEffects(t *testing.T) {
+	script, err := Parse(`
+		# Every kind of effect
+		damage [200/300/450] + 180% AD physical to target
+		damage 240% AP magic to all; heal 20% HP
+		shield 300 + 100% AP for 4s
+		stun lowest for 1500ms
+		buff "Rapid Fire" for [3/4/5]s with +50% AS, +20 AP, -10 Armor
+		call "Custom"
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Script{
+		{Kind: EffectDamage, Amount: Amount{{Value: Value{200, 300, 450}}, {Value: Value{1.8}, Scaling: ScalingAD}}, DamageType: models.DamageTypePhysical},
+		{Kind: EffectDamage, Amount: Amount{{Value: Value{2.4}, Scaling: ScalingAP}}, DamageType: models.DamageTypeMagic, Selector: SelectAll},
+		{Kind: EffectHeal, Amount: Amount{{Value: Value{0.2}, Scaling: ScalingHP}}},
+		{Kind: EffectShield, Amount: Amount{{Value: Value{300}}, {Value: Value{1}, Scaling: ScalingAP}}, Duration: Value{4}},
+		{Kind: EffectStun, Selector: SelectLowestHP, Duration: Value{1.5}},
+		{Kind: EffectBuff, Name: "Rapid Fire", Duration: Value{3, 4, 5}, Stats: map[models.StatType]Value{
+			models.StatAttackSpeed:  {0.5},
+			models.StatAbilityPower: {0.2},
+			models.StatArmor:        {-10},
+		}},
+		{Kind: EffectCall, Name: "Custom"},
+	}
+	if len(script) != len(want) {
+		t.Fatalf("Expected %d effects, got %d: %+v", len(want), len(script), script)
+	}
+	for i := range want {
+		if !effectsEqual(script[i], want[i]) {
+			t.Errorf("Effect %d: expected %+v, got %+v", i, want[i], script[i])
+		}
+	}
+}
+
+// effectsEqual compares effects allowing for float rounding in values
+func effectsEqual(a, b Effect) bool {
+	round := func(v Value) Value {
+		out := make(Value, len(v))
+		for i, x := range v {
+			out[i] = math.Round(x*1e9) / 1e9
+		}
+		return out
+	}
+	for _, e := range []*Effect{&a, &b} {
+		e.Duration = round(e.Duration)
+		amount := make(Amount, len(e.Amount))
+		for i, term := range e.Amount {
+			amount[i] = Term{Value: round(term.Value), Scaling: term.Scaling}
+		}
+		e.Amount = amount
+		if e.Stats != nil {
+			stats := make(map[models.StatType]Value, len(e.Stats))
+			for stat, v := range e.Stats {
+				stats[stat] = round(v)
+			}
+			e.Stats = stats
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"explode 100":                      "unknown effect",
+		"damage 100":                       "expected physical, magic or true",
+		"damage 100 magic to nobody":       "expected target, all, lowest or highest",
+		"damage 50% MR magic":              "expected AD, AP or HP",
+		"damage [1/2 magic":                `expected "/"`,
+		"shield 100 for 4":                 "expected a duration unit",
+		"buff Rapid for 4s with +50% AS":   "quoted buff name",
+		`buff "Rapid" for 4s with +50% XY`: "unknown buff stat",
+		`buff "Rapid" for 4s with +20 AD`:  "must be a percentage",
+		`buff "Rapid" for 4s with +20% HP`: "must be in points",
+		"heal 10 extra":                    "expected end of line",
+		`call "unterminated`:               "unterminated string",
+		"damage 10 magic\ndamage $":        "line 2",
+	}
+	for src, want := range cases {
+		_, err := Parse(src)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q): expected error containing %q, got %v", src, want, err)
+		}
+	}
+}
+
+func TestValueAtStarLevel(t *testing.T) {
+	v := Value{100, 150}
+	for star, want := range map[int]float64{0: 100, 1: 100, 2: 150, 3: 150} {
+		if got := v.At(star); got != want {
+			t.Errorf("Star %d: expected %.0f, got %.0f", star, want, got)
+		}
+	}
+}
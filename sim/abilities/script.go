@@ -0,0 +1,369 @@
+// Package abilities describes champion abilities as small scripts instead
+// of hand-written closures. A script is a list of effects, one per line or
+// separated by semicolons:
+//
+//	damage [200/300/450] + 180% AD physical to target
+//	damage 240% AP magic to all
+//	heal 20% HP
+//	shield 300 + 100% AP for 4s
+//	stun target for [1.5/1.75/2]s
+//	buff "Rapid Fire" for 4s with +50% AS, +20 AP
+//	call "Custom Effect"
+//
+// Numbers in brackets are per star level. Amounts add up terms that are
+// flat or a percentage of the caster's AD, AP or max HP; AP is counted in
+// points like the tooltips, so 100% AP is 100 damage at 100 AP. Buffs give
+// HP, armor, MR, AP and mana regen in points and every other stat as a
+// percentage, and reject the other unit. Targets are
+// selected from the ability's targets with target, all, lowest or highest
+// (by current HP). Effects the language cannot express are written in Go
+// and registered with RegisterFunc for call, or given as Spec.OnCastStart
+// and Spec.OnCast.
+package abilities
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"tft-sim/models"
+	"time"
+)
+
+// Value is a number that may differ by star level
+type Value []float64
+
+// At returns the value for a star level. Values with fewer entries than the
+// star level use their last entry.
+func (v Value) At(starLevel int) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	i := starLevel - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(v) {
+		i = len(v) - 1
+	}
+	return v[i]
+}
+
+// Scaling is the caster stat a term of an amount scales with
+type Scaling int
+
+const (
+	ScalingFlat Scaling = iota
+	ScalingAD
+	ScalingAP
+	ScalingHP
+)
+
+// Term is one part of an amount. Ratio terms hold their percentage as a
+// fraction, 1.8 for 180% AD.
+type Term struct {
+	Value   Value
+	Scaling Scaling
+}
+
+// Amount is a sum of terms, e.g. 200 + 180% AD
+type Amount []Term
+
+// Eval returns the amount for the caster at its current stats
+func (a Amount) Eval(u *models.Unit) float64 {
+	var total float64
+	for _, term := range a {
+		value := term.Value.At(u.StarLevel)
+		switch term.Scaling {
+		case ScalingAD:
+			value *= u.Stats.Get(models.StatAttackDamage)
+		case ScalingAP:
+			// AP is stored as a fraction on top of 100
+			value *= 100 * (1 + u.Stats.Get(models.StatAbilityPower))
+		case ScalingHP:
+			value *= u.Stats.Get(models.StatHealth)
+		}
+		total += value
+	}
+	return total
+}
+
+// Selector picks the targets of an effect from the ability's targets
+type Selector int
+
+const (
+	SelectTarget    Selector = iota // The first target
+	SelectAll                       // Every target
+	SelectLowestHP                  // The target with the least current HP
+	SelectHighestHP                 // The target with the most current HP
+)
+
+// Select returns the living targets the selector picks
+func (s Selector) Select(targets []*models.Target) []*models.Target {
+	alive := make([]*models.Target, 0, len(targets))
+	for _, target := range targets {
+		if !target.IsDead() {
+			alive = append(alive, target)
+		}
+	}
+	if len(alive) == 0 || s == SelectAll {
+		return alive
+	}
+
+	pick := alive[0]
+	for _, target := range alive[1:] {
+		switch s {
+		case SelectLowestHP:
+			if target.CurrentHP < pick.CurrentHP {
+				pick = target
+			}
+		case SelectHighestHP:
+			if target.CurrentHP > pick.CurrentHP {
+				pick = target
+			}
+		}
+	}
+	return []*models.Target{pick}
+}
+
+// EffectKind is what an effect does
+type EffectKind int
+
+const (
+	EffectDamage EffectKind = iota
+	EffectHeal
+	EffectShield
+	EffectStun
+	EffectBuff
+	EffectCall
+)
+
+// Effect is one statement of a script. Only the fields its kind uses are set.
+type Effect struct {
+	Kind       EffectKind
+	Amount     Amount                    // Damage, heal and shield
+	DamageType models.DamageType         // Damage
+	Selector   Selector                  // Damage and stun
+	Duration   Value                     // Seconds, for shield, stun and buff
+	Name       string                    // Buff name or registered function for call
+	Stats      map[models.StatType]Value // Buff stat bonuses, applied like Stats.AddBonus
+}
+
+// Script is a parsed ability script
+type Script []Effect
+
+// Func is a Go effect that a script runs with call
+type Func func(u *models.Unit, targets []*models.Target)
+
+var (
+	funcs = make(map[string]Func)
+	mu    sync.RWMutex
+)
+
+// RegisterFunc registers a Go effect for scripts to call by name. Register
+// from init so the function exists before scripts are compiled.
+func RegisterFunc(name string, fn Func) {
+	mu.Lock()
+	defer mu.Unlock()
+	funcs[name] = fn
+}
+
+// getFunc returns the Go effect registered under name
+func getFunc(name string) (Func, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	fn, exists := funcs[name]
+	return fn, exists
+}
+
+// Run applies every effect of the script in order
+func (s Script) Run(u *models.Unit, targets []*models.Target) {
+	for _, effect := range s {
+		effect.Apply(u, targets)
+	}
+}
+
+// Apply applies the effect for the caster at the current time
+func (e Effect) Apply(u *models.Unit, targets []*models.Target) {
+	now := u.Stats.CurrentTime
+	duration := time.Duration(e.Duration.At(u.StarLevel) * float64(time.Second))
+
+	switch e.Kind {
+	case EffectDamage:
+		amount := e.Amount.Eval(u)
+		for _, target := range e.Selector.Select(targets) {
+			damage, isCrit := abilityDamage(u, target, amount, e.DamageType)
+			u.DealDamage(target, damage, e.DamageType, true, isCrit)
+		}
+	case EffectHeal:
+		u.Heal(e.Amount.Eval(u))
+	case EffectShield:
+		u.AddShield(e.Amount.Eval(u), duration)
+	case EffectStun:
+		for _, target := range e.Selector.Select(targets) {
+			target.Stun(now + duration)
+		}
+	case EffectBuff:
+		buff := models.NewBuff(e.Name, duration)
+		for _, stat := range sortedStats(e.Stats) {
+			buff.AddStatBonus(stat, e.Stats[stat].At(u.StarLevel))
+		}
+		u.BuffManager.ApplyBuff(buff, now)
+	case EffectCall:
+		if fn, exists := getFunc(e.Name); exists {
+			fn(u, targets)
+		}
+	}
+}
+
+// abilityDamage applies crit, damage amp and the target's resistances to
+// ability damage. True damage ignores resistances but is still amplified.
+// Crits are only rolled for abilities that can crit, and are not recorded
+// so the crit rate and streak only reflect auto attacks.
+func abilityDamage(u *models.Unit, target *models.Target, amount float64, damageType models.DamageType) (float64, bool) {
+	isCrit := u.Ability.CanAbilityCrit && u.CritTracker.Roll(u.Stats.Get(models.StatCritChance))
+	switch damageType {
+	case models.DamageTypePhysical:
+		return models.MitigateDamage(u, target, target.Stats.Get(models.StatArmor), amount, isCrit), isCrit
+	case models.DamageTypeMagic:
+		return models.MitigateDamage(u, target, target.Stats.Get(models.StatMagicResist), amount, isCrit), isCrit
+	default:
+		if isCrit {
+			amount *= 1 + u.Stats.Get(models.StatCritDamage)
+		}
+		return amount * (1 + u.Stats.Get(models.StatDamageAmp)), isCrit
+	}
+}
+
+// sortedStats returns the stats of a buff in StatType order so buffs apply
+// deterministically
+func sortedStats(stats map[models.StatType]Value) []models.StatType {
+	keys := make([]models.StatType, 0, len(stats))
+	for stat := range stats {
+		keys = append(keys, stat)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Spec describes an ability in data
+type Spec struct {
+	Name                        string
+	CastTime                    time.Duration
+	Script                      string
+	AllowsManaGainDuringCast    bool
+	AllowsAutoAttacksDuringCast bool
+	CanAbilityCrit              bool
+	ManaLock                    time.Duration
+	IsAoE                       bool // Target every enemy even if no effect selects them
+	IsAutoAttackModifier        bool
+
+	// Waves makes the ability a channel: the script runs this many times
+	// spread evenly over CastTime, the first when the cast starts, instead
+	// of once when the cast completes
+	Waves int
+
+	// OnCastStart and OnCast are Go fallbacks for anything the script
+	// cannot express. OnCastStart runs when the cast starts, after the first
+	// wave of a channel, and OnCast after the script's effects.
+	OnCastStart func(*models.Unit)
+	OnCast      func(*models.Unit, []*models.Target)
+}
+
+// Compile parses the spec's script into an ability. The ability targets
+// every enemy if the spec says so or any effect selects other than the first
+// target, and its DamageType is that of its first damage effect.
+func Compile(spec Spec) (models.Ability, error) {
+	script, err := Parse(spec.Script)
+	if err != nil {
+		return models.Ability{}, fmt.Errorf("ability %s: %w", spec.Name, err)
+	}
+
+	ability := models.Ability{
+		Name:                        spec.Name,
+		CastTime:                    spec.CastTime,
+		AllowsManaGainDuringCast:    spec.AllowsManaGainDuringCast,
+		AllowsAutoAttacksDuringCast: spec.AllowsAutoAttacksDuringCast,
+		CanAbilityCrit:              spec.CanAbilityCrit,
+		ManaLock:                    spec.ManaLock,
+		IsAoE:                       spec.IsAoE,
+		IsAutoAttackModifier:        spec.IsAutoAttackModifier,
+	}
+
+	damageType := -1
+	for _, effect := range script {
+		switch effect.Kind {
+		case EffectDamage:
+			if damageType < 0 {
+				damageType = int(effect.DamageType)
+			}
+			fallthrough
+		case EffectStun:
+			if effect.Selector != SelectTarget {
+				ability.IsAoE = true
+			}
+		case EffectCall:
+			if _, exists := getFunc(effect.Name); !exists {
+				return models.Ability{}, fmt.Errorf("ability %s: function %q is not registered", spec.Name, effect.Name)
+			}
+		}
+	}
+	if damageType >= 0 {
+		ability.DamageType = models.DamageType(damageType)
+	}
+
+	fallback := spec.OnCast
+	ability.OnCastStart = spec.OnCastStart
+	if spec.Waves > 1 {
+		waves, start := channel(spec.Name, script, spec.Waves, spec.CastTime), spec.OnCastStart
+		ability.OnCastStart = func(u *models.Unit) {
+			waves(u)
+			if start != nil {
+				start(u)
+			}
+		}
+	}
+	ability.OnCast = func(u *models.Unit, targets []*models.Target) {
+		if spec.Waves <= 1 {
+			script.Run(u, targets)
+		}
+		if fallback != nil {
+			fallback(u, targets)
+		}
+	}
+
+	return ability, nil
+}
+
+// channel returns an OnCastStart that runs the script in waves over the
+// cast. The waves are driven by a buff lasting the cast, whose OnTick runs
+// every wave that is due.
+func channel(name string, script Script, waves int, castTime time.Duration) func(*models.Unit) {
+	interval := castTime / time.Duration(waves)
+	return func(u *models.Unit) {
+		if u.CastingCtx == nil {
+			return
+		}
+		targets := u.CastingCtx.Targets
+		script.Run(u, targets)
+
+		fired := 1
+		buff := models.NewBuff(name+" Channel", castTime)
+		buff.OnTick = func(u *models.Unit, elapsed time.Duration) {
+			for fired < waves && elapsed >= time.Duration(fired)*interval {
+				fired++
+				script.Run(u, targets)
+			}
+		}
+		u.BuffManager.ApplyBuff(buff, u.Stats.CurrentTime)
+	}
+}
+
+// MustCompile is Compile for abilities defined in code, it panics if the
+// script does not parse
+func MustCompile(spec Spec) models.Ability {
+	ability, err := Compile(spec)
+	if err != nil {
+		panic(err)
+	}
+	return ability
+}
@@ -0,0 +1,205 @@
+package abilities
+
+import (
+	"math"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+)
+
+func newScriptTestUnit(t *testing.T, spec Spec) *models.Unit {
+	t.Helper()
+	ability, err := Compile(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 100,
+		models.StatAttackSpeed:  0.5,
+		models.StatMana:         0,
+	}
+	template := models.Unit{Name: "Caster", UnitRole: models.RoleMagicCaster, StarLevel: 2}
+	return models.NewUnit(template, ability, baseStats, 2)
+}
+
+func TestCompileDerivesAbilityShape(t *testing.T) {
+	single, err := Compile(Spec{Name: "Bolt", Script: "damage 100 magic to target; stun target for 1s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.IsAoE || single.DamageType != models.DamageTypeMagic || single.OnCast == nil {
+		t.Errorf("Expected a single target magic ability, got %+v", single)
+	}
+
+	aoe, err := Compile(Spec{Name: "Volley", Script: "buff \"Fury\" for 1s with +10% AD\ndamage 100 physical to all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !aoe.IsAoE || aoe.DamageType != models.DamageTypePhysical {
+		t.Errorf("Expected an AoE physical ability, got %+v", aoe)
+	}
+
+	if _, err := Compile(Spec{Name: "Broken", Script: "call \"Missing\""}); err == nil {
+		t.Error("Expected an error for an unregistered function")
+	}
+	if _, err := Compile(Spec{Name: "Broken", Script: "damage"}); err == nil {
+		t.Error("Expected an error for an invalid script")
+	}
+}
+
+func TestScriptDamageScalesWithStarAndAP(t *testing.T) {
+	unit := newScriptTestUnit(t, Spec{Name: "Nuke", Script: "damage [100/200/300] + 50% AD + 150% AP magic to all"})
+	unit.Stats.AddBonus(models.StatAbilityPower, 0.20)
+	unit.CritTracker.Seed(1)
+
+	targets := []*models.Target{models.NewTarget("A", 1e6, 0, 100), models.NewTarget("B", 1e6, 0, 100)}
+	unit.Ability.OnCast(unit, targets)
+
+	// 200 at 2 stars + 50 from AD + 180 from 120 AP, halved by 100 MR
+	want := (200 + 50 + 180) * 0.5
+	for _, target := range targets {
+		if got := target.MaxHP - target.CurrentHP; math.Abs(got-want) > 1e-9 {
+			t.Errorf("Expected %.1f damage to %s, got %.1f", want, target.Name, got)
+		}
+	}
+	if unit.TotalDamage != 2*want {
+		t.Errorf("Expected the damage to be dealt through the unit, got %.1f", unit.TotalDamage)
+	}
+}
+
+func TestScriptSupportEffects(t *testing.T) {
+	unit := newScriptTestUnit(t, Spec{Name: "Support", Script: `
+		heal 10% HP
+		shield 100 + 50% AP for 2s
+		stun highest for [1/2/3]s
+		buff "Rally" for 4s with +50% AS, +30 AP
+	`})
+	unit.CurrentHealth = 500
+	unit.Stats.SetCurrentTime(time.Second)
+
+	low, high := models.NewTarget("Low", 100, 0, 0), models.NewTarget("High", 200, 0, 0)
+	unit.Ability.OnCast(unit, []*models.Target{low, high})
+
+	if unit.CurrentHealth != 600 {
+		t.Errorf("Expected to heal 10%% of max HP, got %.0f health", unit.CurrentHealth)
+	}
+	if unit.Shield != 150 || unit.ShieldExpires != 3*time.Second {
+		t.Errorf("Expected a 150 shield until 3s, got %.0f until %v", unit.Shield, unit.ShieldExpires)
+	}
+	if low.IsStunned(time.Second) || !high.IsStunned(2900*time.Millisecond) || high.IsStunned(3*time.Second) {
+		t.Errorf("Expected only the highest HP target stunned for 2s, got %v and %v", low.StunnedUntil, high.StunnedUntil)
+	}
+	if got := unit.Stats.Get(models.StatAttackSpeed); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("Expected 50%% bonus attack speed from the buff, got %.3f", got)
+	}
+	if got := unit.Stats.Get(models.StatAbilityPower); math.Abs(got-0.30) > 1e-9 {
+		t.Errorf("Expected 30 AP from the buff, got %.3f", got)
+	}
+}
+
+func TestScriptGoFallbacks(t *testing.T) {
+	var called, fallback int
+	RegisterFunc("Test Count", func(*models.Unit, []*models.Target) { called++ })
+	unit := newScriptTestUnit(t, Spec{
+		Name:   "Hybrid",
+		Script: `call "Test Count"`,
+		OnCast: func(*models.Unit, []*models.Target) {
+			if called != 1 {
+				t.Error("Expected the script to run before the Go fallback")
+			}
+			fallback++
+		},
+	})
+
+	unit.Ability.OnCast(unit, []*models.Target{models.NewTarget("Dummy", 100, 0, 0)})
+	if called != 1 || fallback != 1 {
+		t.Errorf("Expected the called function and fallback to run once, got %d and %d", called, fallback)
+	}
+}
+
+func TestGoOnlyAbility(t *testing.T) {
+	var started int
+	ability, err := Compile(Spec{
+		Name:                 "Stance",
+		CastTime:             time.Second,
+		IsAoE:                true,
+		IsAutoAttackModifier: true,
+		OnCastStart:          func(*models.Unit) { started++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ability.IsAoE || !ability.IsAutoAttackModifier || ability.OnCastStart == nil {
+		t.Fatalf("Expected the spec's flags and cast start fallback, got %+v", ability)
+	}
+
+	ability.OnCastStart(nil)
+	if started != 1 {
+		t.Errorf("Expected the cast start fallback to run once, got %d", started)
+	}
+}
+
+func TestScriptedAbilityInSimulation(t *testing.T) {
+	unit := newScriptTestUnit(t, Spec{Name: "Bolt", CastTime: 500 * time.Millisecond, Script: "damage 100 true to target"})
+	unit.Stats.SetBase(models.StatMana, 20)
+
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 1e6, 50, 50)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 10 * time.Second
+	result := simulator.Run()
+
+	casts := len(result.CastWindows)
+	if casts == 0 || result.DamageBySource["Ability"] != 100*float64(casts) {
+		t.Errorf("Expected 100 true damage per cast, got %.1f over %d casts", result.DamageBySource["Ability"], casts)
+	}
+}
+
+func TestChannelRunsWavesOverCast(t *testing.T) {
+	unit := newScriptTestUnit(t, Spec{Name: "Barrage", CastTime: time.Second, Waves: 4, Script: "damage 10 true to target"})
+	unit.Stats.SetBase(models.StatMana, 1000)
+	unit.Stats.SetBase(models.StatStartingMana, 1000)
+
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 1e6, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 1200 * time.Millisecond
+	result := simulator.Run()
+
+	var waves []time.Duration
+	for _, event := range result.DamageLog {
+		if event.IsAbility {
+			waves = append(waves, event.Timestamp)
+		}
+	}
+	if len(waves) != 4 || result.DamageBySource["Ability"] != 40 {
+		t.Fatalf("Expected 4 waves of 10 damage, got %v", waves)
+	}
+	for i, at := range waves {
+		if want := time.Duration(i) * 250 * time.Millisecond; at < want || at >= want+20*time.Millisecond {
+			t.Errorf("Expected wave %d at %v, got %v", i+1, want, at)
+		}
+	}
+}
+
+func TestAbilityCritsAreNotRecorded(t *testing.T) {
+	for _, canCrit := range []bool{false, true} {
+		unit := newScriptTestUnit(t, Spec{Name: "Volley", Script: "damage 100 true to all", CanAbilityCrit: canCrit})
+		unit.Stats.AddBonus(models.StatCritChance, 1)
+		unit.Stats.AddBonus(models.StatCritDamage, 0.5)
+		targets := []*models.Target{models.NewTarget("A", 5000, 0, 0), models.NewTarget("B", 5000, 0, 0)}
+
+		unit.Ability.OnCast(unit, targets)
+
+		if tracker := unit.CritTracker; tracker.TotalAttacks != 0 || tracker.TotalCrits != 0 || tracker.CritStreak != 0 {
+			t.Errorf("CanAbilityCrit %v: expected ability hits left out of the crit tracker, got %+v", canCrit, tracker)
+		}
+		want := 100.0
+		if canCrit {
+			want = 150
+		}
+		if got := targets[0].Stats.Get(models.StatHealth) - targets[0].CurrentHP; math.Abs(got-want) > 1e-9 {
+			t.Errorf("CanAbilityCrit %v: expected %v damage, got %v", canCrit, want, got)
+		}
+	}
+}
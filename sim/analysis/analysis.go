@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+// DefaultSeeds is the number of seeded runs averaged for each evaluation
+const DefaultSeeds = 20
+
+// z95 is the two-sided 95% normal quantile used for confidence intervals
+const z95 = 1.96
+
+// Build is a named item set
+type Build struct {
+	Name  string
+	Items []string
+}
+
+// Scenario is the unit and fight setup shared by every run of an analysis
+type Scenario struct {
+	sweep.Config
+
+	// Base fixes the fight parameters. Use single values; the analysis
+	// replaces the dimension it varies along with ItemSets and Seeds, and
+	// empty dimensions use the sweep defaults.
+	Base sweep.Grid
+
+	Seeds int // Seeded runs per evaluation, zero uses DefaultSeeds
+}
+
+// grid returns the base grid with the builds and one entry per seed
+func (s Scenario) grid(builds ...Build) sweep.Grid {
+	grid := s.Base
+	grid.ItemSets = nil
+	for _, b := range builds {
+		grid.ItemSets = append(grid.ItemSets, b.Items)
+	}
+
+	seeds := s.Seeds
+	if seeds <= 0 {
+		seeds = DefaultSeeds
+	}
+	grid.Seeds = make([]int64, seeds)
+	for i := range grid.Seeds {
+		grid.Seeds[i] = int64(i + 1)
+	}
+	return grid
+}
+
+// setDimension points a grid dimension at a single value
+func setDimension(grid *sweep.Grid, dim sweep.Dimension, value float64) error {
+	switch dim {
+	case sweep.DimArmor:
+		grid.Armor = []float64{value}
+	case sweep.DimMagicResist:
+		grid.MagicResist = []float64{value}
+	case sweep.DimTargetHP:
+		grid.TargetHP = []float64{value}
+	case sweep.DimDuration:
+		grid.Durations = []time.Duration{time.Duration(value * float64(time.Second))}
+	default:
+		return fmt.Errorf("cannot analyse over %s, use armor, magic resist, target HP or duration", dim)
+	}
+	return nil
+}
+
+// HigherIsBetter reports whether larger values of the metric mean a
+// stronger build
+func HigherIsBetter(metric sweep.Metric) bool {
+	return metric != sweep.MetricTimeToKill
+}
+
+// metricValue reads a row's metric. A target that survived counts as killed
+// at the end of the fight so time to kill stays comparable; this
+// underestimates the true time to kill of builds that often fail to kill.
+func metricValue(row sweep.Row, metric sweep.Metric) float64 {
+	if metric == sweep.MetricTimeToKill && row.TimeToKill < 0 {
+		return row.Duration.Seconds()
+	}
+	return row.Metric(metric)
+}
+
+// Summary is the mean of a metric over seeded runs
+type Summary struct {
+	Mean   float64
+	StdDev float64
+	StdErr float64
+	N      int
+}
+
+// CI95 returns the 95% confidence interval of the mean
+func (s Summary) CI95() (low, high float64) {
+	return s.Mean - z95*s.StdErr, s.Mean + z95*s.StdErr
+}
+
+// summarize computes the mean, sample standard deviation and standard error
+func summarize(values []float64) Summary {
+	n := len(values)
+	if n == 0 {
+		return Summary{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(n)
+
+	if n == 1 {
+		return Summary{Mean: mean, N: 1}
+	}
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(sq / float64(n-1))
+	return Summary{Mean: mean, StdDev: std, StdErr: std / math.Sqrt(float64(n)), N: n}
+}
@@ -0,0 +1,229 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tft-sim/sim/sweep"
+)
+
+// BreakEvenConfig controls a break-even search
+type BreakEvenConfig struct {
+	Dimension     sweep.Dimension // Armor, magic resist, target HP or duration
+	Min, Max      float64
+	Metric        sweep.Metric
+	Steps         int     // Coarse scan intervals between Min and Max, zero means 10
+	Tolerance     float64 // Bisection stops at this bracket width, zero means 0.5% of the range
+	MaxIterations int     // Bisection steps per crossover, zero means 20
+}
+
+func (c BreakEvenConfig) withDefaults() BreakEvenConfig {
+	if c.Steps <= 0 {
+		c.Steps = 10
+	}
+	if c.Tolerance <= 0 {
+		c.Tolerance = (c.Max - c.Min) / 200
+	}
+	if c.MaxIterations <= 0 {
+		c.MaxIterations = 20
+	}
+	return c
+}
+
+// CurvePoint is both builds evaluated at one value of the swept dimension
+type CurvePoint struct {
+	Value  float64
+	Builds [2]Summary
+	// Advantage is the paired per-seed lead of the first build, positive
+	// when it is ahead on the metric
+	Advantage Summary
+}
+
+// z returns how many standard errors the advantage is from zero
+func (p CurvePoint) z() float64 {
+	if p.Advantage.StdErr == 0 {
+		switch {
+		case p.Advantage.Mean > 0:
+			return math.Inf(1)
+		case p.Advantage.Mean < 0:
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	return p.Advantage.Mean / p.Advantage.StdErr
+}
+
+// Crossover is a value where the leading build changes
+type Crossover struct {
+	Value     float64    // Interpolated value where the mean advantage is zero
+	Bracket   [2]float64 // Final bisection bracket around Value
+	Leader    int        // Index of the build ahead above Value
+	Interval  [2]float64 // Values around Value where neither build is ahead at 95% confidence
+	Confident bool       // Each build is ahead at 95% confidence on its side of Interval
+}
+
+// BreakEvenResult holds the evaluated curves and crossovers of two builds
+type BreakEvenResult struct {
+	Builds     [2]Build
+	Dimension  sweep.Dimension
+	Metric     sweep.Metric
+	Curve      []CurvePoint // Every evaluated value, sorted
+	Crossovers []Crossover
+}
+
+// BreakEven finds where along a target property one build overtakes the
+// other. It scans the range on a coarse grid, then bisects each interval
+// where the mean advantage changes sign. Both builds use the same seeds at
+// every value so the comparison is paired.
+func BreakEven(scn Scenario, builds [2]Build, cfg BreakEvenConfig) (BreakEvenResult, error) {
+	cfg = cfg.withDefaults()
+	if cfg.Max <= cfg.Min {
+		return BreakEvenResult{}, fmt.Errorf("break-even range is empty: [%g, %g]", cfg.Min, cfg.Max)
+	}
+
+	result := BreakEvenResult{Builds: builds, Dimension: cfg.Dimension, Metric: cfg.Metric}
+	evaluated := make(map[float64]CurvePoint)
+	eval := func(value float64) (CurvePoint, error) {
+		if p, ok := evaluated[value]; ok {
+			return p, nil
+		}
+		p, err := evaluate(scn, builds, cfg.Dimension, value, cfg.Metric)
+		if err != nil {
+			return CurvePoint{}, err
+		}
+		evaluated[value] = p
+		return p, nil
+	}
+
+	// Coarse scan
+	coarse := make([]CurvePoint, cfg.Steps+1)
+	for i := range coarse {
+		value := cfg.Min + (cfg.Max-cfg.Min)*float64(i)/float64(cfg.Steps)
+		p, err := eval(value)
+		if err != nil {
+			return BreakEvenResult{}, err
+		}
+		coarse[i] = p
+	}
+
+	// Bisect each sign change
+	for i := 1; i < len(coarse); i++ {
+		lo, hi := coarse[i-1], coarse[i]
+		if sign(lo.Advantage.Mean) == sign(hi.Advantage.Mean) || sign(hi.Advantage.Mean) == 0 {
+			continue
+		}
+
+		for iter := 0; iter < cfg.MaxIterations && hi.Value-lo.Value > cfg.Tolerance; iter++ {
+			mid, err := eval((lo.Value + hi.Value) / 2)
+			if err != nil {
+				return BreakEvenResult{}, err
+			}
+			if sign(mid.Advantage.Mean) == sign(lo.Advantage.Mean) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		result.Crossovers = append(result.Crossovers, newCrossover(lo, hi))
+	}
+
+	for _, p := range evaluated {
+		result.Curve = append(result.Curve, p)
+	}
+	sort.Slice(result.Curve, func(i, j int) bool { return result.Curve[i].Value < result.Curve[j].Value })
+
+	for i := range result.Crossovers {
+		result.Crossovers[i].setInterval(result.Curve)
+	}
+
+	return result, nil
+}
+
+// newCrossover interpolates the zero of the advantage between two points
+func newCrossover(lo, hi CurvePoint) Crossover {
+	c := Crossover{Value: lo.Value, Bracket: [2]float64{lo.Value, hi.Value}}
+	if d := hi.Advantage.Mean - lo.Advantage.Mean; d != 0 {
+		c.Value = lo.Value - lo.Advantage.Mean*(hi.Value-lo.Value)/d
+	}
+	if hi.Advantage.Mean < 0 {
+		c.Leader = 1
+	}
+	return c
+}
+
+// setInterval widens the crossover to the nearest evaluated values on each
+// side where one build is ahead at 95% confidence
+func (c *Crossover) setInterval(curve []CurvePoint) {
+	c.Interval = [2]float64{curve[0].Value, curve[len(curve)-1].Value}
+	var below, above float64
+
+	for i := len(curve) - 1; i >= 0; i-- {
+		if curve[i].Value <= c.Value && math.Abs(curve[i].z()) >= z95 {
+			c.Interval[0] = curve[i].Value
+			below = curve[i].z()
+			break
+		}
+	}
+	for _, p := range curve {
+		if p.Value >= c.Value && math.Abs(p.z()) >= z95 {
+			c.Interval[1] = p.Value
+			above = p.z()
+			break
+		}
+	}
+
+	// The lead must be significant on both sides and actually switch
+	c.Confident = below != 0 && above != 0 && sign(below) != sign(above)
+}
+
+// evaluate runs both builds at one value with paired seeds
+func evaluate(scn Scenario, builds [2]Build, dim sweep.Dimension, value float64, metric sweep.Metric) (CurvePoint, error) {
+	grid := scn.grid(builds[0], builds[1])
+	if err := setDimension(&grid, dim, value); err != nil {
+		return CurvePoint{}, err
+	}
+
+	table, err := sweep.Run(scn.Config, grid)
+	if err != nil {
+		return CurvePoint{}, err
+	}
+
+	var values [2][]float64
+	bySeed := make(map[int64]float64)
+	for _, row := range table.Rows {
+		v := metricValue(row, metric)
+		values[row.ItemSet] = append(values[row.ItemSet], v)
+		if row.ItemSet == 0 {
+			bySeed[row.Seed] = v
+		}
+	}
+
+	var advantage []float64
+	for _, row := range table.Rows {
+		if row.ItemSet != 1 {
+			continue
+		}
+		d := bySeed[row.Seed] - metricValue(row, metric)
+		if !HigherIsBetter(metric) {
+			d = -d
+		}
+		advantage = append(advantage, d)
+	}
+
+	return CurvePoint{
+		Value:     value,
+		Builds:    [2]Summary{summarize(values[0]), summarize(values[1])},
+		Advantage: summarize(advantage),
+	}, nil
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+var testScenario = Scenario{
+	Config: sweep.Config{Unit: "Yunara"},
+	Base:   sweep.Grid{Durations: []time.Duration{10 * time.Second}},
+	Seeds:  4,
+}
+
+func point(value, advantage, stdErr float64) CurvePoint {
+	return CurvePoint{Value: value, Advantage: Summary{Mean: advantage, StdErr: stdErr}}
+}
+
+func TestCrossoverInterpolation(t *testing.T) {
+	c := newCrossover(point(100, 2, 1), point(200, -6, 1))
+	if math.Abs(c.Value-125) > 1e-9 {
+		t.Errorf("Expected crossover at 125, got %.2f", c.Value)
+	}
+	if c.Leader != 1 {
+		t.Errorf("Expected second build to lead above the crossover, got %d", c.Leader)
+	}
+}
+
+func TestCrossoverInterval(t *testing.T) {
+	curve := []CurvePoint{
+		point(0, 5, 1),  // Significant, first build ahead
+		point(10, 1, 1), // Not significant
+		point(20, -1, 1),
+		point(30, -5, 1), // Significant, second build ahead
+	}
+
+	c := newCrossover(curve[1], curve[2])
+	c.setInterval(curve)
+	if c.Interval != [2]float64{0, 30} || !c.Confident {
+		t.Errorf("Expected confident interval [0, 30], got %v confident=%v", c.Interval, c.Confident)
+	}
+
+	// Noise around zero with no significant lead on one side
+	curve[3] = point(30, -1, 1)
+	c.setInterval(curve)
+	if c.Confident {
+		t.Error("Expected crossover without a significant lead on both sides to not be confident")
+	}
+}
+
+func TestBreakEvenSameBuildHasNoCrossover(t *testing.T) {
+	build := Build{Name: "IE", Items: []string{"IE"}}
+	result, err := BreakEven(testScenario, [2]Build{build, build}, BreakEvenConfig{
+		Dimension: sweep.DimArmor, Min: 0, Max: 200, Steps: 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Crossovers) != 0 {
+		t.Errorf("Expected no crossovers, got %+v", result.Crossovers)
+	}
+	if len(result.Curve) != 5 {
+		t.Errorf("Expected 5 coarse points, got %d", len(result.Curve))
+	}
+	for _, p := range result.Curve {
+		if p.Advantage.Mean != 0 || p.Builds[0].N != 4 {
+			t.Errorf("Expected identical paired runs at %g, got %+v", p.Value, p)
+		}
+	}
+}
+
+func TestBreakEvenFindsCrossover(t *testing.T) {
+	// Deathblade kills small targets faster, Guinsoos ramps up for large ones
+	builds := [2]Build{
+		{Name: "Deathblade", Items: []string{"Deathblade", "Red", "IE"}},
+		{Name: "Guinsoos", Items: []string{"Guinsoos", "Titans", "IE"}},
+	}
+	scn := testScenario
+	scn.Base = sweep.Grid{Durations: []time.Duration{60 * time.Second}}
+
+	result, err := BreakEven(scn, builds, BreakEvenConfig{
+		Dimension: sweep.DimTargetHP, Min: 500, Max: 8000, Steps: 6, Metric: sweep.MetricTimeToKill,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Crossovers) == 0 {
+		t.Fatal("Expected a crossover")
+	}
+	last := result.Crossovers[len(result.Crossovers)-1]
+	if last.Leader != 1 {
+		t.Errorf("Expected Guinsoos to lead for large targets, got %+v", last)
+	}
+	if last.Value < last.Bracket[0] || last.Value > last.Bracket[1] || last.Bracket[1]-last.Bracket[0] > (8000-500)/200.0 {
+		t.Errorf("Crossover %g outside bracket %v or bracket too wide", last.Value, last.Bracket)
+	}
+}
+
+func TestBreakEvenRejectsBadInput(t *testing.T) {
+	build := Build{Name: "IE", Items: []string{"IE"}}
+	if _, err := BreakEven(testScenario, [2]Build{build, build}, BreakEvenConfig{Dimension: sweep.DimItems, Min: 0, Max: 1}); err == nil {
+		t.Error("Expected error for a dimension that is not a target property")
+	}
+	if _, err := BreakEven(testScenario, [2]Build{build, build}, BreakEvenConfig{Dimension: sweep.DimArmor, Min: 10, Max: 10}); err == nil {
+		t.Error("Expected error for an empty range")
+	}
+}
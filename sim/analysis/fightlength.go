@@ -0,0 +1,151 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"tft-sim/sim"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+// FightLength is one possible fight duration and its relative weight
+type FightLength struct {
+	Duration time.Duration
+	Weight   float64
+}
+
+// FightLengths is a discrete distribution of fight durations. Weights are
+// relative and need not sum to one.
+type FightLengths []FightLength
+
+// UniformFightLengths weights every duration from min to max in steps of
+// step equally
+func UniformFightLengths(min, max, step time.Duration) FightLengths {
+	var lengths FightLengths
+	for d := min; d <= max && step > 0; d += step {
+		lengths = append(lengths, FightLength{Duration: d, Weight: 1})
+	}
+	return lengths
+}
+
+// TriangularFightLengths weights durations from min to max in steps of step,
+// peaking at mode and falling linearly to zero just outside the range
+func TriangularFightLengths(min, mode, max, step time.Duration) FightLengths {
+	var lengths FightLengths
+	for d := min; d <= max && step > 0; d += step {
+		var weight float64
+		if d <= mode {
+			weight = float64(d-min+step) / float64(mode-min+step)
+		} else {
+			weight = float64(max-d+step) / float64(max-mode+step)
+		}
+		lengths = append(lengths, FightLength{Duration: d, Weight: weight})
+	}
+	return lengths
+}
+
+// validate checks the distribution can be evaluated
+func (f FightLengths) validate() error {
+	if len(f) == 0 {
+		return fmt.Errorf("no fight lengths given")
+	}
+	var total float64
+	for _, l := range f {
+		if l.Duration <= 0 {
+			return fmt.Errorf("fight length %s must be positive", l.Duration)
+		}
+		if l.Weight < 0 {
+			return fmt.Errorf("fight length %s has negative weight %g", l.Duration, l.Weight)
+		}
+		total += l.Weight
+	}
+	if total <= 0 {
+		return fmt.Errorf("fight length weights sum to zero")
+	}
+	return nil
+}
+
+// Max returns the longest duration in the distribution
+func (f FightLengths) Max() time.Duration {
+	var max time.Duration
+	for _, l := range f {
+		if l.Duration > max {
+			max = l.Duration
+		}
+	}
+	return max
+}
+
+// DamageAt returns the cumulative damage of a run at the end of fights of
+// each duration. The fight is deterministic up to that point, so one run of
+// the longest duration stands in for a run of every shorter one.
+func DamageAt(result sim.SimulationResult, durations []time.Duration) []float64 {
+	dot := result.DamageOverTime
+	damage := make([]float64, len(durations))
+	for i, d := range durations {
+		// A fight of length d ends before the tick at d
+		j := sort.Search(len(dot), func(j int) bool { return dot[j].Timestamp >= d })
+		if j > 0 {
+			damage[i] = dot[j-1].CumulativeDamage
+		}
+	}
+	return damage
+}
+
+// ExpectedDamage is a build's damage weighted over a fight length
+// distribution
+type ExpectedDamage struct {
+	Build    Build
+	Damage   Summary   // Expected total damage, over seeds
+	DPS      Summary   // Expected damage per second of fight, over seeds
+	ByLength []Summary // Damage at the end of each fight length, over seeds
+}
+
+// ExpectedDamages scores builds over a distribution of fight lengths rather
+// than one fixed duration, so items that need a long fight to ramp up are
+// not over-rewarded. Each build runs once per seed for the longest length
+// and every shorter length is read from its damage over time. The
+// scenario's durations are ignored.
+func ExpectedDamages(scn Scenario, builds []Build, lengths FightLengths) ([]ExpectedDamage, error) {
+	if err := lengths.validate(); err != nil {
+		return nil, err
+	}
+
+	durations := make([]time.Duration, len(lengths))
+	var totalWeight float64
+	for i, l := range lengths {
+		durations[i] = l.Duration
+		totalWeight += l.Weight
+	}
+
+	scores := make([]ExpectedDamage, len(builds))
+	for i, b := range builds {
+		grid := scn.grid(b)
+		grid.Durations = []time.Duration{lengths.Max()}
+
+		var expected, expectedDPS []float64
+		byLength := make([][]float64, len(lengths))
+		for _, point := range grid.Points() {
+			result, err := sweep.Simulate(scn.Config, point)
+			if err != nil {
+				return nil, err
+			}
+
+			var damage, dps float64
+			for j, d := range DamageAt(result, durations) {
+				byLength[j] = append(byLength[j], d)
+				damage += lengths[j].Weight * d
+				dps += lengths[j].Weight * d / durations[j].Seconds()
+			}
+			expected = append(expected, damage/totalWeight)
+			expectedDPS = append(expectedDPS, dps/totalWeight)
+		}
+
+		scores[i] = ExpectedDamage{Build: b, Damage: summarize(expected), DPS: summarize(expectedDPS)}
+		for _, values := range byLength {
+			scores[i].ByLength = append(scores[i].ByLength, summarize(values))
+		}
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"tft-sim/sim"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+func TestFightLengthDistributions(t *testing.T) {
+	uniform := UniformFightLengths(10*time.Second, 12*time.Second, time.Second)
+	if len(uniform) != 3 || uniform[2].Duration != 12*time.Second || uniform[1].Weight != 1 {
+		t.Errorf("Unexpected uniform lengths %v", uniform)
+	}
+
+	triangular := TriangularFightLengths(10*time.Second, 12*time.Second, 14*time.Second, time.Second)
+	var weights []float64
+	for _, l := range triangular {
+		weights = append(weights, l.Weight)
+	}
+	if want := []float64{1.0 / 3, 2.0 / 3, 1, 2.0 / 3, 1.0 / 3}; !reflect.DeepEqual(weights, want) {
+		t.Errorf("Expected weights %v, got %v", want, weights)
+	}
+	if triangular.Max() != 14*time.Second {
+		t.Errorf("Expected max 14s, got %v", triangular.Max())
+	}
+}
+
+func TestFightLengthsValidate(t *testing.T) {
+	invalid := map[string]FightLengths{
+		"empty":           nil,
+		"zero duration":   {{Duration: 0, Weight: 1}},
+		"negative weight": {{Duration: time.Second, Weight: -1}},
+		"zero weights":    {{Duration: time.Second}},
+	}
+	for name, lengths := range invalid {
+		if err := lengths.validate(); err == nil {
+			t.Errorf("Expected error for %s", name)
+		}
+	}
+}
+
+func TestDamageAt(t *testing.T) {
+	result := sim.SimulationResult{DamageOverTime: []sim.DamageOverTime{
+		{Timestamp: 1 * time.Second, CumulativeDamage: 100},
+		{Timestamp: 2 * time.Second, CumulativeDamage: 250},
+	}}
+
+	got := DamageAt(result, []time.Duration{time.Second, 1500 * time.Millisecond, 5 * time.Second})
+	if want := []float64{0, 100, 250}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestDamageAtMatchesShorterRun(t *testing.T) {
+	cfg := sweep.Config{Unit: "Yunara"}
+	point := sweep.Grid{}.Points()[0]
+	point.Items = []string{"Guinsoos", "Titans"}
+
+	point.Duration = 20 * time.Second
+	long, err := sweep.Simulate(cfg, point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point.Duration = 7 * time.Second
+	short, err := sweep.Simulate(cfg, point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := DamageAt(long, []time.Duration{7 * time.Second})[0]; math.Abs(got-short.TotalDamage) > 1e-6 {
+		t.Errorf("Expected damage at 7s of a long run to match a 7s run, got %.1f vs %.1f", got, short.TotalDamage)
+	}
+}
+
+func TestExpectedDamages(t *testing.T) {
+	builds := []Build{{Name: "Guinsoos", Items: []string{"Guinsoos"}}}
+	lengths := FightLengths{{Duration: 5 * time.Second, Weight: 3}, {Duration: 10 * time.Second, Weight: 1}}
+
+	scores, err := ExpectedDamages(testScenario, builds, lengths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	score := scores[0]
+	if len(score.ByLength) != 2 || score.Damage.N != 4 {
+		t.Fatalf("Unexpected score shape %+v", score)
+	}
+
+	short, long := score.ByLength[0].Mean, score.ByLength[1].Mean
+	if want := (3*short + long) / 4; math.Abs(score.Damage.Mean-want) > 1e-6 {
+		t.Errorf("Expected weighted damage %.1f, got %.1f", want, score.Damage.Mean)
+	}
+	if want := (3*short/5 + long/10) / 4; math.Abs(score.DPS.Mean-want) > 1e-6 {
+		t.Errorf("Expected weighted DPS %.1f, got %.1f", want, score.DPS.Mean)
+	}
+
+	// A start delay costs damage in every fight
+	delayed := testScenario
+	delayed.StartDelay = 2 * time.Second
+	delayedScores, err := ExpectedDamages(delayed, builds, lengths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delayedScores[0].Damage.Mean >= score.Damage.Mean {
+		t.Errorf("Expected a start delay to lower expected damage, got %.1f vs %.1f", delayedScores[0].Damage.Mean, score.Damage.Mean)
+	}
+}
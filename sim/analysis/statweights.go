@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tft-sim/models"
+	"tft-sim/sim/sweep"
+)
+
+// DefaultStatSteps are the perturbations used when StatWeightConfig.Steps is
+// empty. They are sized like a component so the gains compare directly:
+// 10% AD, 10 AP, 10% attack speed and so on. AP, crit and damage amp are
+// stored as fractions like the percent stats.
+var DefaultStatSteps = map[models.StatType]float64{
+	models.StatAttackDamage: 0.10,
+	models.StatAbilityPower: 0.10,
+	models.StatAttackSpeed:  0.10,
+	models.StatCritChance:   0.10,
+	models.StatCritDamage:   0.10,
+	models.StatManaRegen:    1,
+	models.StatDamageAmp:    0.05,
+}
+
+// StatWeightConfig controls a stat weight calculation
+type StatWeightConfig struct {
+	Metric sweep.Metric
+	Steps  map[models.StatType]float64 // Bonus added to each stat, nil uses DefaultStatSteps
+}
+
+// StatWeight is the marginal value of one stat for a build
+type StatWeight struct {
+	Stat models.StatType
+	Step float64
+	// Gain is the paired per-seed improvement from adding Step, positive
+	// when the metric gets better
+	Gain Summary
+	// PerUnit is Gain divided by Step. Most stats are fractions, so this is
+	// usually the gain per +100%; use Per for a readable amount.
+	PerUnit Summary
+}
+
+// Per scales the weight to the gain from the given amount of the stat
+func (w StatWeight) Per(amount float64) Summary {
+	return w.PerUnit.scale(amount)
+}
+
+// StatWeightResult holds every stat's weight for one build
+type StatWeightResult struct {
+	Build    Build
+	Metric   sweep.Metric
+	Baseline Summary      // The build without any extra stats
+	Weights  []StatWeight // Sorted by descending mean gain
+}
+
+// StatWeights measures how much each stat is worth to a build. Every stat is
+// raised by its step on its own and rerun on the same seeds as the
+// baseline, so crit rolls line up and the paired differences have far less
+// noise than two independent runs.
+func StatWeights(scn Scenario, build Build, cfg StatWeightConfig) (StatWeightResult, error) {
+	steps := cfg.Steps
+	if steps == nil {
+		steps = DefaultStatSteps
+	}
+
+	stats := make([]models.StatType, 0, len(steps))
+	for stat, step := range steps {
+		if step == 0 {
+			return StatWeightResult{}, fmt.Errorf("stat weight step for %s must not be zero", stat)
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i] < stats[j] })
+
+	grid := scn.grid(build)
+	baseline, err := runMetric(scn.Config, grid, cfg.Metric)
+	if err != nil {
+		return StatWeightResult{}, err
+	}
+
+	result := StatWeightResult{Build: build, Metric: cfg.Metric, Baseline: summarize(baseline)}
+	for _, stat := range stats {
+		step := steps[stat]
+
+		// Copy the bonuses so the scenario's own map is never modified
+		perturbed := scn.Config
+		perturbed.Bonuses = make(map[models.StatType]float64, len(scn.Bonuses)+1)
+		for s, v := range scn.Bonuses {
+			perturbed.Bonuses[s] = v
+		}
+		perturbed.Bonuses[stat] += step
+
+		values, err := runMetric(perturbed, grid, cfg.Metric)
+		if err != nil {
+			return StatWeightResult{}, err
+		}
+
+		gains := make([]float64, len(values))
+		for i := range values {
+			gains[i] = values[i] - baseline[i]
+			if !HigherIsBetter(cfg.Metric) {
+				gains[i] = -gains[i]
+			}
+		}
+
+		gain := summarize(gains)
+		result.Weights = append(result.Weights, StatWeight{
+			Stat:    stat,
+			Step:    step,
+			Gain:    gain,
+			PerUnit: gain.scale(1 / step),
+		})
+	}
+
+	sort.SliceStable(result.Weights, func(i, j int) bool {
+		return result.Weights[i].Gain.Mean > result.Weights[j].Gain.Mean
+	})
+
+	return result, nil
+}
+
+// runMetric runs a grid and returns the metric of every row in grid order
+func runMetric(cfg sweep.Config, grid sweep.Grid, metric sweep.Metric) ([]float64, error) {
+	table, err := sweep.Run(cfg, grid)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, len(table.Rows))
+	for i, row := range table.Rows {
+		values[i] = metricValue(row, metric)
+	}
+	return values, nil
+}
+
+// scale multiplies a summary by a constant
+func (s Summary) scale(k float64) Summary {
+	return Summary{Mean: s.Mean * k, StdDev: s.StdDev * math.Abs(k), StdErr: s.StdErr * math.Abs(k), N: s.N}
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim/sweep"
+)
+
+func TestStatWeights(t *testing.T) {
+	scn := testScenario
+	scn.Bonuses = map[models.StatType]float64{models.StatAttackSpeed: 0.2}
+	build := Build{Name: "IE", Items: []string{"IE"}}
+
+	result, err := StatWeights(scn, build, StatWeightConfig{
+		Metric: sweep.MetricDPS,
+		Steps: map[models.StatType]float64{
+			models.StatAttackDamage: 0.10,
+			models.StatArmor:        20,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scn.Bonuses) != 1 {
+		t.Errorf("Expected scenario bonuses to be left alone, got %v", scn.Bonuses)
+	}
+	if result.Baseline.N != 4 || result.Baseline.Mean <= 0 {
+		t.Errorf("Unexpected baseline %+v", result.Baseline)
+	}
+	if len(result.Weights) != 2 {
+		t.Fatalf("Expected 2 weights, got %d", len(result.Weights))
+	}
+
+	ad, armor := result.Weights[0], result.Weights[1]
+	if ad.Stat != models.StatAttackDamage || ad.Gain.Mean <= 0 {
+		t.Errorf("Expected attack damage to be worth DPS and sorted first, got %+v", ad)
+	}
+	if low, _ := ad.Gain.CI95(); low <= 0 {
+		t.Errorf("Expected paired seeds to make the attack damage gain significant, got %+v", ad.Gain)
+	}
+	if math.Abs(ad.Per(0.10).Mean-ad.Gain.Mean) > 1e-9 {
+		t.Errorf("Expected Per(step) to match the gain, got %.4f vs %.4f", ad.Per(0.10).Mean, ad.Gain.Mean)
+	}
+
+	// Own armor does nothing against a training dummy
+	if armor.Gain.Mean != 0 || armor.Gain.StdErr != 0 {
+		t.Errorf("Expected no gain from armor, got %+v", armor.Gain)
+	}
+}
+
+func TestStatWeightsRejectsZeroStep(t *testing.T) {
+	_, err := StatWeights(testScenario, Build{}, StatWeightConfig{
+		Steps: map[models.StatType]float64{models.StatAttackDamage: 0},
+	})
+	if err == nil {
+		t.Error("Expected error for a zero step")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := summarize([]float64{1, 2, 3, 4})
+	if s.Mean != 2.5 || s.N != 4 {
+		t.Errorf("Unexpected summary %+v", s)
+	}
+	if math.Abs(s.StdDev-math.Sqrt(5.0/3)) > 1e-9 || math.Abs(s.StdErr-s.StdDev/2) > 1e-9 {
+		t.Errorf("Unexpected spread %+v", s)
+	}
+
+	scaled := s.scale(-2)
+	if scaled.Mean != -5 || scaled.StdErr != 2*s.StdErr {
+		t.Errorf("Unexpected scaled summary %+v", scaled)
+	}
+}
@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"tft-sim/sim"
+	"tft-sim/sim/sweep"
+	"time"
+)
+
+// profileHP is the target HP used for TTK profile runs, large enough that
+// the target never dies and the whole fight is recorded
+const profileHP = 1e12
+
+// HPRange returns steps+1 evenly spaced HP thresholds from min to max
+func HPRange(min, max float64, steps int) []float64 {
+	if steps <= 0 {
+		return []float64{min}
+	}
+	thresholds := make([]float64, steps+1)
+	for i := range thresholds {
+		thresholds[i] = min + (max-min)*float64(i)/float64(steps)
+	}
+	return thresholds
+}
+
+// KillTimes returns when the cumulative damage of a run first reaches each
+// HP threshold, or -1 for thresholds the run never reached. A target with
+// that much HP and the same resists would have died at that time, as long as
+// no effect depends on the target's HP.
+func KillTimes(result sim.SimulationResult, thresholds []float64) []time.Duration {
+	dot := result.DamageOverTime
+	times := make([]time.Duration, len(thresholds))
+	for i, hp := range thresholds {
+		// Cumulative damage never decreases, so search for the first crossing
+		j := sort.Search(len(dot), func(j int) bool { return dot[j].CumulativeDamage >= hp })
+		if j == len(dot) {
+			times[i] = -1
+			continue
+		}
+		times[i] = dot[j].Timestamp
+	}
+	return times
+}
+
+// TTKPoint is the time to kill a target with the given HP
+type TTKPoint struct {
+	HP      float64
+	Seconds Summary // Over the seeds that reached HP
+	Reached int     // Seeds whose damage reached HP within the fight
+	Runs    int     // Seeds run
+}
+
+// Complete reports whether every seed reached the threshold. The mean of an
+// incomplete point only covers the faster runs.
+func (p TTKPoint) Complete() bool {
+	return p.Runs > 0 && p.Reached == p.Runs
+}
+
+// TTKCurve is a build's time to kill across target HP pools
+type TTKCurve struct {
+	Build  Build
+	Points []TTKPoint // In threshold order
+}
+
+// TTKProfiles runs each build once per seed against a target that cannot
+// die and reads the time to kill for every HP threshold from the damage
+// over time. Thresholds beyond what a build deals within the scenario's
+// duration are not reached, so use a long enough fight.
+func TTKProfiles(scn Scenario, builds []Build, thresholds []float64) ([]TTKCurve, error) {
+	if len(thresholds) == 0 {
+		return nil, fmt.Errorf("no HP thresholds given")
+	}
+
+	curves := make([]TTKCurve, len(builds))
+	for i, b := range builds {
+		grid := scn.grid(b)
+		grid.TargetHP = []float64{profileHP}
+
+		points := grid.Points()
+		samples := make([][]float64, len(thresholds))
+		for _, point := range points {
+			result, err := sweep.Simulate(scn.Config, point)
+			if err != nil {
+				return nil, err
+			}
+			for j, t := range KillTimes(result, thresholds) {
+				if t >= 0 {
+					samples[j] = append(samples[j], t.Seconds())
+				}
+			}
+		}
+
+		curves[i] = TTKCurve{Build: b, Points: make([]TTKPoint, len(thresholds))}
+		for j, hp := range thresholds {
+			curves[i].Points[j] = TTKPoint{HP: hp, Seconds: summarize(samples[j]), Reached: len(samples[j]), Runs: len(points)}
+		}
+	}
+
+	return curves, nil
+}
@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"tft-sim/sim"
+	"time"
+)
+
+func TestHPRange(t *testing.T) {
+	if got := HPRange(500, 1500, 4); !reflect.DeepEqual(got, []float64{500, 750, 1000, 1250, 1500}) {
+		t.Errorf("Unexpected range %v", got)
+	}
+	if got := HPRange(500, 1500, 0); !reflect.DeepEqual(got, []float64{500}) {
+		t.Errorf("Expected a single threshold without steps, got %v", got)
+	}
+}
+
+func TestKillTimes(t *testing.T) {
+	result := sim.SimulationResult{DamageOverTime: []sim.DamageOverTime{
+		{Timestamp: 1 * time.Second, CumulativeDamage: 100},
+		{Timestamp: 2 * time.Second, CumulativeDamage: 250},
+		{Timestamp: 3 * time.Second, CumulativeDamage: 400},
+	}}
+
+	got := KillTimes(result, []float64{250, 50, 401, 300})
+	want := []time.Duration{2 * time.Second, 1 * time.Second, -1, 3 * time.Second}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestTTKProfiles(t *testing.T) {
+	builds := []Build{{Name: "IE", Items: []string{"IE"}}, {Name: "No items"}}
+	curves, err := TTKProfiles(testScenario, builds, []float64{100, 400, 1e9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(curves) != 2 {
+		t.Fatalf("Expected 2 curves, got %d", len(curves))
+	}
+
+	for _, curve := range curves {
+		small, large, unreachable := curve.Points[0], curve.Points[1], curve.Points[2]
+		if !small.Complete() || !large.Complete() || small.Runs != 4 {
+			t.Errorf("%s: expected both targets killed by every seed, got %+v %+v", curve.Build.Name, small, large)
+		}
+		if small.Seconds.Mean >= large.Seconds.Mean {
+			t.Errorf("%s: expected more HP to take longer, got %.2fs vs %.2fs", curve.Build.Name, small.Seconds.Mean, large.Seconds.Mean)
+		}
+		if unreachable.Complete() || unreachable.Reached != 0 {
+			t.Errorf("%s: expected 1e9 HP to survive, got %+v", curve.Build.Name, unreachable)
+		}
+	}
+
+	if curves[0].Points[1].Seconds.Mean >= curves[1].Points[1].Seconds.Mean {
+		t.Error("Expected Infinity Edge to kill faster than no items")
+	}
+
+	if _, err := TTKProfiles(testScenario, builds, nil); err == nil {
+		t.Error("Expected error without thresholds")
+	}
+}
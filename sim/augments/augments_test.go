@@ -0,0 +1,126 @@
+package augments
+
+import (
+	"math"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+)
+
+func newAugmentUnit() *models.Unit {
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 100,
+		models.StatAttackSpeed:  1.0,
+		models.StatMana:         1000,
+	}
+	template := models.Unit{Name: "Test Unit", UnitRole: models.RoleAttackMarksman, StarLevel: 1}
+	return models.NewUnit(template, models.Ability{Name: "None"}, baseStats, 2)
+}
+
+func runAugmentSim(t *testing.T, unit *models.Unit, duration time.Duration) (*sim.Simulator, sim.SimulationResult) {
+	t.Helper()
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 1e9, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = duration
+	return simulator, simulator.Run()
+}
+
+func TestStarterAugmentsRegistered(t *testing.T) {
+	for _, name := range []string{"Combat Training", "Magic Wand", "Flurry of Blows", "Pumping Up", "Final Ascension", "Manaflow"} {
+		augment, exists := Get(name)
+		if !exists {
+			t.Errorf("Augment %s not found in registry", name)
+			continue
+		}
+		if augment.Description == "" {
+			t.Errorf("Augment %s has empty description", name)
+		}
+	}
+}
+
+func TestApplyTeamStats(t *testing.T) {
+	unit := newAugmentUnit()
+	if err := Apply(unit, []string{"Combat Training"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := unit.Stats.Get(models.StatAttackDamage); math.Abs(got-110) > 1e-9 {
+		t.Errorf("Expected Combat Training to give 110 AD, got %.2f", got)
+	}
+
+	if err := Apply(unit, []string{"Not An Augment"}); err == nil {
+		t.Error("Expected error for unknown augment")
+	}
+}
+
+func TestPumpingUpStacksOverTime(t *testing.T) {
+	unit := newAugmentUnit()
+	if err := Apply(unit, []string{"Pumping Up"}); err != nil {
+		t.Fatal(err)
+	}
+
+	simulator, _ := runAugmentSim(t, unit, 10*time.Second)
+	unit.Stats.SetCurrentTime(simulator.Time)
+
+	// 8% at combat start plus 1% at 3, 6 and 9 seconds
+	if got := unit.Stats.Get(models.StatAttackSpeed); math.Abs(got-1.11) > 1e-9 {
+		t.Errorf("Expected 1.11 attack speed after 10s, got %.4f", got)
+	}
+}
+
+func TestFinalAscensionAfterFifteenSeconds(t *testing.T) {
+	unit := newAugmentUnit()
+	if err := Apply(unit, []string{"Final Ascension"}); err != nil {
+		t.Fatal(err)
+	}
+
+	simulator, _ := runAugmentSim(t, unit, 14*time.Second)
+	if unit.BuffManager.HasBuff("Final Ascension", simulator.Time) {
+		t.Error("Final Ascension should not activate before 15 seconds")
+	}
+
+	unit = newAugmentUnit()
+	if err := Apply(unit, []string{"Final Ascension"}); err != nil {
+		t.Fatal(err)
+	}
+	simulator, _ = runAugmentSim(t, unit, 16*time.Second)
+	unit.Stats.SetCurrentTime(simulator.Time)
+	if got := unit.Stats.Get(models.StatDamageAmp); math.Abs(got-0.45) > 1e-9 {
+		t.Errorf("Expected 45%% damage amp after 15 seconds, got %.4f", got)
+	}
+}
+
+func TestManaflowGrantsManaPerAttack(t *testing.T) {
+	plain := newAugmentUnit()
+	_, _ = runAugmentSim(t, plain, 5*time.Second)
+
+	unit := newAugmentUnit()
+	if err := Apply(unit, []string{"Manaflow"}); err != nil {
+		t.Fatal(err)
+	}
+	_, results := runAugmentSim(t, unit, 5*time.Second)
+
+	want := plain.CurrentMana + 2*float64(results.AttackCount)
+	if unit.CurrentMana != want {
+		t.Errorf("Expected %.0f mana with Manaflow, got %.0f", want, unit.CurrentMana)
+	}
+}
+
+func TestAugmentOnHitDispatched(t *testing.T) {
+	hits := 0
+	unit := newAugmentUnit()
+	unit.AddAugment(models.Augment{
+		Name:        "Test On Hit",
+		OnHitEffect: func(*models.Unit, *models.Target, float64) { hits++ },
+	})
+
+	_, results := runAugmentSim(t, unit, 5*time.Second)
+	if hits == 0 || hits != results.AttackCount {
+		t.Errorf("Expected one on-hit call per attack, got %d for %d attacks", hits, results.AttackCount)
+	}
+	if len(results.Augments) != 1 || results.Augments[0] != "Test On Hit" {
+		t.Errorf("Unexpected result augments: %v", results.Augments)
+	}
+}
@@ -0,0 +1,15 @@
+package augments
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Combat Training",
+		Description: "Your team gains 10% Attack Damage",
+		TeamStats: map[models.StatType]float64{
+			models.StatAttackDamage: .10,
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package augments
+
+import (
+	"tft-sim/models"
+	"time"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Final Ascension",
+		Description: "Your team gains 15% Damage Amp. After 15 seconds of combat, they gain an additional 30% Damage Amp",
+		TeamStats: map[models.StatType]float64{
+			models.StatDamageAmp: .15,
+		},
+		OnSecondEffect: func(unit *models.Unit) {
+			currentTime := unit.Stats.CurrentTime
+			if currentTime < 15*time.Second || unit.BuffManager.HasBuff("Final Ascension", currentTime) {
+				return
+			}
+
+			buff := models.NewBuff("Final Ascension", 0)
+			buff.AddStatBonus(models.StatDamageAmp, .30)
+			unit.BuffManager.ApplyBuff(buff, currentTime)
+		},
+	})
+}
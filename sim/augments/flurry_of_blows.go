@@ -0,0 +1,16 @@
+package augments
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Flurry of Blows",
+		Description: "Your team gains 15% Critical Strike Chance and 15% Critical Strike Damage",
+		TeamStats: map[models.StatType]float64{
+			models.StatCritChance: .15,
+			models.StatCritDamage: .15,
+		},
+	})
+}
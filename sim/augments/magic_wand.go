@@ -0,0 +1,15 @@
+package augments
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Magic Wand",
+		Description: "Your team gains 10 Ability Power",
+		TeamStats: map[models.StatType]float64{
+			models.StatAbilityPower: .10,
+		},
+	})
+}
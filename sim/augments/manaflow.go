@@ -0,0 +1,15 @@
+package augments
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Manaflow",
+		Description: "Your team gains 2 additional Mana per attack",
+		TeamStats: map[models.StatType]float64{
+			models.StatManaPerAttack: 2,
+		},
+	})
+}
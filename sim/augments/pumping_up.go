@@ -0,0 +1,30 @@
+package augments
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Augment{
+		Name:        "Pumping Up",
+		Description: "Your team gains 8% Attack Speed at the start of combat, plus 1% more every 3 seconds",
+		OnCombatStartEffect: func(unit *models.Unit) {
+			unit.BuffManager.ApplyBuff(pumpingUpBuff(0.08), unit.Stats.CurrentTime)
+		},
+		OnSecondEffect: func(unit *models.Unit) {
+			currentTime := unit.Stats.CurrentTime
+			if int(currentTime.Seconds())%3 != 0 {
+				return
+			}
+			unit.BuffManager.ApplyBuff(pumpingUpBuff(0.01), currentTime)
+		},
+	})
+}
+
+// pumpingUpBuff creates a permanent attack speed stack for Pumping Up
+func pumpingUpBuff(attackSpeed float64) *models.Buff {
+	buff := models.NewBuff("Pumping Up", 0)
+	buff.SetStacking(1000, models.StackBehaviorAdditive)
+	buff.AddStatBonus(models.StatAttackSpeed, attackSpeed)
+	return buff
+}
@@ -0,0 +1,49 @@
+package augments
+
+import (
+	"fmt"
+	"sync"
+	"tft-sim/models"
+)
+
+var (
+	registry = make(map[string]models.Augment)
+	mu       sync.RWMutex
+)
+
+func Register(augment models.Augment) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[augment.Name] = augment
+}
+
+func Get(name string) (models.Augment, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	augment, exists := registry[name]
+	return augment, exists
+}
+
+func GetAll() map[string]models.Augment {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	// Return a copy
+	copy := make(map[string]models.Augment)
+	for k, v := range registry {
+		copy[k] = v
+	}
+	return copy
+}
+
+// Apply looks up each named augment and attaches it to the unit
+func Apply(unit *models.Unit, names []string) error {
+	for _, name := range names {
+		augment, exists := Get(name)
+		if !exists {
+			return fmt.Errorf("augment %s not found in registry", name)
+		}
+		unit.AddAugment(augment)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package sim
+
+import (
+	"fmt"
+	"tft-sim/models"
+	"time"
+)
+
+// EventType identifies a combat event
+type EventType int
+
+const (
+	// EventStateChange is a change of the unit's state, see CombatEvent.From and To
+	EventStateChange EventType = iota
+	// EventAttackStart is the start of an auto attack windup
+	EventAttackStart
+	// EventAttackRelease is an auto attack firing after its windup. Melee
+	// attacks land at once, ranged attacks launch a projectile.
+	EventAttackRelease
+	// EventAttackCancel is an attack dropped during its windup
+	EventAttackCancel
+	// EventAttackLand is an auto attack dealing its damage
+	EventAttackLand
+	// EventProjectileMiss is a projectile whose target died before it arrived
+	EventProjectileMiss
+)
+
+var eventTypeNames = map[EventType]string{
+	EventStateChange:    "State Change",
+	EventAttackStart:    "Attack Start",
+	EventAttackRelease:  "Attack Release",
+	EventAttackCancel:   "Attack Cancel",
+	EventAttackLand:     "Attack Land",
+	EventProjectileMiss: "Projectile Miss",
+}
+
+func (e EventType) String() string {
+	if name, ok := eventTypeNames[e]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// CombatEvent is one entry of SimulationResult.Events
+type CombatEvent struct {
+	Timestamp time.Duration
+	Type      EventType
+	From, To  models.UnitState // Only set for EventStateChange
+	Target    string           // Attacked target, empty for state changes
+}
+
+func (e CombatEvent) String() string {
+	if e.Type == EventStateChange {
+		return fmt.Sprintf("[%.3fs] %s -> %s", e.Timestamp.Seconds(), e.From, e.To)
+	}
+	return fmt.Sprintf("[%.3fs] %s on %s", e.Timestamp.Seconds(), e.Type, e.Target)
+}
+
+// recordEvent appends an event to the result if events are being recorded
+func (s *Simulator) recordEvent(event CombatEvent) {
+	if !s.Config.RecordEvents {
+		return
+	}
+	event.Timestamp = s.Time
+	s.Results.Events = append(s.Results.Events, event)
+}
+
+// onStateChange is installed as the unit's state handler
+func (s *Simulator) onStateChange(from, to models.UnitState) {
+	s.recordEvent(CombatEvent{Type: EventStateChange, From: from, To: to})
+}
+
+// attackHit is a released auto attack waiting to deal its damage
+type attackHit struct {
+	target     *models.Target
+	damage     float64
+	damageType models.DamageType
+	isCrit     bool
+	landTime   time.Duration
+}
+
+// startAttack begins an attack windup on the first living target
+func (s *Simulator) startAttack() {
+	target := s.findTarget()
+	if target == nil {
+		return
+	}
+	s.Unit.StartAttack(s.Time, target)
+	s.recordEvent(CombatEvent{Type: EventAttackStart, Target: target.Name})
+}
+
+// cancelAttack drops the attack winding up
+func (s *Simulator) cancelAttack(reason string) {
+	target := s.Unit.Attack.Target
+	s.Unit.CancelAttack()
+	s.recordEvent(CombatEvent{Type: EventAttackCancel, Target: target.Name})
+
+	if s.Config.Verbose {
+		fmt.Printf("[%.2fs] %s cancels an attack on %s (%s)\n", s.Time.Seconds(), s.Unit.Name, target.Name, reason)
+	}
+}
+
+// landProjectiles resolves every projectile that has reached its target,
+// keeping the rest in flight
+func (s *Simulator) landProjectiles() {
+	// Most ticks have nothing landing, skip rewriting the slice for them
+	due := false
+	for i := range s.projectiles {
+		if s.Time >= s.projectiles[i].landTime {
+			due = true
+			break
+		}
+	}
+	if !due {
+		return
+	}
+
+	inFlight := s.projectiles[:0]
+	for _, hit := range s.projectiles {
+		if s.Time < hit.landTime {
+			inFlight = append(inFlight, hit)
+			continue
+		}
+		if hit.target.IsDead() {
+			s.missProjectile(hit)
+			continue
+		}
+		s.landAttack(hit)
+	}
+	s.projectiles = inFlight
+}
+
+// dropProjectiles ends the fight for projectiles still in flight. Those
+// headed for a dead target, such as when the last target died, are wasted;
+// the rest would have landed after the fight and are left out.
+func (s *Simulator) dropProjectiles() {
+	for _, hit := range s.projectiles {
+		if hit.target.IsDead() {
+			s.missProjectile(hit)
+		}
+	}
+	s.projectiles = s.projectiles[:0]
+}
+
+// missProjectile records a projectile that reached a dead target
+func (s *Simulator) missProjectile(hit attackHit) {
+	s.Results.WastedAttacks++
+	s.Results.WastedDamage += hit.damage
+	s.recordEvent(CombatEvent{Type: EventProjectileMiss, Target: hit.target.Name})
+}
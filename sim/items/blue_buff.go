@@ -0,0 +1,21 @@
+package items
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Item{
+		Name:        "Blue",
+		Description: "Max Mana reduced by 10. After casting an ability, gain 10 Mana.",
+		Stats: map[models.StatType]float64{
+			models.StatAbilityPower: 0.20,
+			models.StatAttackDamage: 0.20,
+			models.StatMana:         -10,
+		},
+		OnAbilityCastComplete: func(itemInstance *models.ItemInstance) {
+			itemInstance.Owner.AddMana(itemInstance.Item.Scaled(10))
+		},
+		Unique: true,
+	})
+}
@@ -0,0 +1,38 @@
+package items
+
+import (
+	"tft-sim/models"
+)
+
+// Component item names, used by the recipe table
+const (
+	BFSword          = "B.F. Sword"
+	RecurveBow       = "Recurve Bow"
+	NeedlesslyLarge  = "Needlessly Large Rod"
+	TearOfTheGoddess = "Tear of the Goddess"
+	ChainVest        = "Chain Vest"
+	NegatronCloak    = "Negatron Cloak"
+	GiantsBelt       = "Giant's Belt"
+	SparringGloves   = "Sparring Gloves"
+	Spatula          = "Spatula"
+)
+
+func init() {
+	components := []models.Item{
+		{Name: BFSword, Stats: map[models.StatType]float64{models.StatAttackDamage: 0.10}},
+		{Name: RecurveBow, Stats: map[models.StatType]float64{models.StatAttackSpeed: 0.10}},
+		{Name: NeedlesslyLarge, Stats: map[models.StatType]float64{models.StatAbilityPower: 0.10}},
+		{Name: TearOfTheGoddess, Stats: map[models.StatType]float64{models.StatStartingMana: 15}},
+		{Name: ChainVest, Stats: map[models.StatType]float64{models.StatArmor: 20}},
+		{Name: NegatronCloak, Stats: map[models.StatType]float64{models.StatMagicResist: 20}},
+		{Name: GiantsBelt, Stats: map[models.StatType]float64{models.StatHealth: 150}},
+		{Name: SparringGloves, Stats: map[models.StatType]float64{models.StatCritChance: 0.20}},
+		{Name: Spatula, Stats: map[models.StatType]float64{}},
+	}
+
+	for _, component := range components {
+		component.Description = "Component. Combine two components into a completed item."
+		component.Category = models.ItemCategoryComponent
+		Register(component)
+	}
+}
@@ -0,0 +1,27 @@
+package items
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Item{
+		Name:        "Marksman Emblem",
+		Description: "The holder gains the Marksman trait.",
+		Stats: map[models.StatType]float64{
+			models.StatAttackDamage: 0.10,
+		},
+		Category:    models.ItemCategoryEmblem,
+		GrantsTrait: "Marksman",
+	})
+
+	Register(models.Item{
+		Name:        "Duelist Emblem",
+		Description: "The holder gains the Duelist trait.",
+		Stats: map[models.StatType]float64{
+			models.StatAttackSpeed: 0.10,
+		},
+		Category:    models.ItemCategoryEmblem,
+		GrantsTrait: "Duelist",
+	})
+}
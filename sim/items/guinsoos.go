@@ -14,7 +14,8 @@ func init() {
 		},
 		OnSecondEffect: func(itemInstance *models.ItemInstance) {
 			unit := itemInstance.Owner
-			unit.Stats.AddBonus(models.StatAttackSpeed, 0.07)
+			unit.Stats.AddBonus(models.StatAttackSpeed, itemInstance.Item.Scaled(0.07))
+			itemInstance.Stacks++
 		},
 		Stacking:  true,
 		MaxStacks: 10000,
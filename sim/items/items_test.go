@@ -0,0 +1,34 @@
+package items
+
+import (
+	"testing"
+	"tft-sim/models"
+	"time"
+)
+
+// newItemTestUnit creates a caster with 100 AD and 60 max mana for item tests
+func newItemTestUnit(traits ...string) *models.Unit {
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 100,
+		models.StatAttackSpeed:  1.0,
+		models.StatMana:         60,
+	}
+	template := models.Unit{Name: "Test Unit", UnitRole: models.RoleMagicCaster, StarLevel: 1, Traits: traits}
+	return models.NewUnit(template, models.Ability{Name: "Nuke", CastTime: 500 * time.Millisecond}, baseStats, 2)
+}
+
+// equip adds registered items to the unit
+func equip(t *testing.T, unit *models.Unit, names ...string) *models.Unit {
+	t.Helper()
+	for _, name := range names {
+		item, exists := Get(name)
+		if !exists {
+			t.Fatalf("%s item not found in registry", name)
+		}
+		if err := unit.AddItem(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return unit
+}
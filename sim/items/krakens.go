@@ -33,37 +33,36 @@ func init() {
 
 			buffName := fmt.Sprintf("Krakens%d", itemIndex)
 
+			// Check current stack count to see if we should add AS bonus
+			currentStacks := 0
+			if existingBuff := unit.BuffManager.GetBuff(buffName, currentTime); existingBuff != nil {
+				currentStacks = existingBuff.CurrentStacks
+			}
+
+			// Nothing left to gain once fully stacked
+			if currentStacks >= 15 {
+				return
+			}
+
 			// Create a buff for this Krakens item
 			buff := models.NewBuff(buffName, 0)
 			buff.SetStacking(15, models.StackBehaviorAdditive)
 
 			// Each application adds 3.5% AD (will stack additively up to 15 times)
-			buff.AddStatBonus(models.StatAttackDamage, 0.035)
-
-			// Check current stack count to see if we should add AS bonus
-			currentStacks := 0
-			for _, existingBuff := range unit.BuffManager.GetActiveBuffs(currentTime) {
-				if existingBuff.Name == buffName {
-					currentStacks = existingBuff.CurrentStacks
-					break
-				}
-			}
+			buff.AddStatBonus(models.StatAttackDamage, itemInstance.Item.Scaled(0.035))
 
 			// If we're about to reach 15 stacks (currently at 14), add AS bonus
 			// Only add it once when transitioning from 14 to 15 stacks
 			if currentStacks == 14 {
-				buff.AddStatBonus(models.StatAttackSpeed, 0.15)
+				buff.AddStatBonus(models.StatAttackSpeed, itemInstance.Item.Scaled(0.15))
 			}
 
 			// Apply the buff (will refresh and stack if already exists)
 			unit.BuffManager.ApplyBuff(buff, currentTime)
 
 			// Update item instance stacks
-			for _, activeBuff := range unit.BuffManager.GetActiveBuffs(currentTime) {
-				if activeBuff.Name == buffName {
-					itemInstance.Stacks = activeBuff.CurrentStacks
-					break
-				}
+			if activeBuff := unit.BuffManager.GetBuff(buffName, currentTime); activeBuff != nil {
+				itemInstance.Stacks = activeBuff.CurrentStacks
 			}
 		},
 		Unique: false,
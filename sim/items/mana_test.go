@@ -0,0 +1,62 @@
+package items
+
+import (
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+)
+
+func TestTearGrantsStartingMana(t *testing.T) {
+	unit := equip(t, newItemTestUnit(), TearOfTheGoddess)
+	if got := unit.Stats.Get(models.StatMana); got != 60 {
+		t.Errorf("Expected Tear to leave max mana at 60, got %.0f", got)
+	}
+	unit.ResetMana()
+	if unit.CurrentMana != 15 {
+		t.Errorf("Expected Tear to grant 15 starting mana, got %.0f", unit.CurrentMana)
+	}
+}
+
+func TestBlueBuffReducesMaxManaAndRefundsCasts(t *testing.T) {
+	unit := equip(t, newItemTestUnit(), "Blue")
+	if got := unit.Stats.Get(models.StatMana); got != 50 {
+		t.Fatalf("Expected Blue Buff to reduce max mana to 50, got %.0f", got)
+	}
+
+	unit.CurrentMana = 50
+	unit.StartCastingAbility(0, nil)
+	unit.CompleteCast(500 * time.Millisecond)
+	if unit.CurrentMana != 10 {
+		t.Errorf("Expected 10 mana restored after casting, got %.0f", unit.CurrentMana)
+	}
+
+	radiant := equip(t, newItemTestUnit(), RadiantName("Blue"))
+	if got := radiant.Stats.Get(models.StatMana); got != 40 {
+		t.Errorf("Expected Radiant Blue Buff to reduce max mana to 40, got %.0f", got)
+	}
+}
+
+func TestShojinGrantsManaPerAttack(t *testing.T) {
+	unit := equip(t, newItemTestUnit(), "Shojin")
+	if got := unit.ManaPerAttack(); got != 12 {
+		t.Errorf("Expected 7 caster mana plus 5 from Shojin per attack, got %.0f", got)
+	}
+}
+
+func TestManaItemsCastMoreOften(t *testing.T) {
+	casts := func(items ...string) int {
+		unit := equip(t, newItemTestUnit(), items...)
+		simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 1e9, 0, 0)})
+		simulator.Config.Verbose = false
+		simulator.Config.Duration = 15 * time.Second
+		return len(simulator.Run().CastWindows)
+	}
+
+	base := casts()
+	for _, item := range []string{"Blue", "Shojin"} {
+		if got := casts(item); got <= base {
+			t.Errorf("Expected %s to cast more than %d times, got %d", item, base, got)
+		}
+	}
+}
@@ -12,6 +12,6 @@ func init() {
 			models.StatAttackSpeed: .65,
 			models.StatDamageAmp:   .15,
 		},
-		Unique: false,
+		Category: models.ItemCategoryArtifact,
 	})
 }
@@ -0,0 +1,56 @@
+package items
+
+import (
+	"strings"
+	"tft-sim/models"
+)
+
+const (
+	// RadiantPrefix is prepended to a completed item's name for its radiant variant
+	RadiantPrefix = "Radiant "
+
+	// RadiantStatScale multiplies a radiant item's base stats
+	RadiantStatScale = 2.0
+
+	// RadiantEffectScale multiplies a radiant item's effect values
+	RadiantEffectScale = 1.5
+)
+
+// RadiantName returns the name of an item's radiant variant
+func RadiantName(name string) string {
+	if strings.HasPrefix(name, RadiantPrefix) {
+		return name
+	}
+	return RadiantPrefix + name
+}
+
+// RadiantNames converts a build's item names to their radiant variants.
+// Items without a radiant variant, such as artifacts and emblems, are kept.
+func RadiantNames(names []string) []string {
+	radiant := make([]string, len(names))
+	for i, name := range names {
+		radiant[i] = name
+		if _, exists := Get(RadiantName(name)); exists {
+			radiant[i] = RadiantName(name)
+		}
+	}
+	return radiant
+}
+
+// NewRadiant generates the radiant variant of a completed item. Stats are
+// scaled by RadiantStatScale and effects by RadiantEffectScale; triggers and
+// uniqueness carry over from the base item.
+func NewRadiant(base models.Item) models.Item {
+	radiant := base
+	radiant.Name = RadiantName(base.Name)
+	radiant.Description = "Radiant: " + base.Description
+	radiant.Category = models.ItemCategoryRadiant
+	radiant.BaseItem = base.Name
+	radiant.EffectScale = RadiantEffectScale
+
+	radiant.Stats = make(map[models.StatType]float64, len(base.Stats))
+	for stat, value := range base.Stats {
+		radiant.Stats[stat] = value * RadiantStatScale
+	}
+	return radiant
+}
@@ -0,0 +1,61 @@
+package items
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"tft-sim/models"
+)
+
+func TestRadiantVariantsRegistered(t *testing.T) {
+	for name, item := range GetAll() {
+		if item.Category != models.ItemCategoryCompleted {
+			if _, exists := Get(RadiantName(name)); exists && item.Category != models.ItemCategoryRadiant {
+				t.Errorf("%s item %s should not have a radiant variant", item.Category, name)
+			}
+			continue
+		}
+
+		radiant, exists := Get(RadiantName(name))
+		if !exists {
+			t.Errorf("Radiant variant of %s not registered", name)
+			continue
+		}
+		if radiant.Category != models.ItemCategoryRadiant || radiant.BaseItem != name {
+			t.Errorf("%s has category %s and base %q", radiant.Name, radiant.Category, radiant.BaseItem)
+		}
+		if radiant.IsUnique() != item.IsUnique() {
+			t.Errorf("%s uniqueness should match its base item", radiant.Name)
+		}
+		for stat, value := range item.Stats {
+			if got := radiant.Stats[stat]; math.Abs(got-value*RadiantStatScale) > 1e-9 {
+				t.Errorf("%s %s = %.3f, want %.3f", radiant.Name, stat, got, value*RadiantStatScale)
+			}
+		}
+	}
+}
+
+func TestRadiantEffectsScaled(t *testing.T) {
+	unit := newItemTestUnit()
+	radiant, _ := Get("Radiant Guinsoos")
+	if err := unit.AddItem(radiant); err != nil {
+		t.Fatal(err)
+	}
+
+	before := unit.Stats.Get(models.StatAttackSpeed)
+	unit.Items[0].Item.OnSecondEffect(&unit.Items[0])
+	gained := unit.Stats.Get(models.StatAttackSpeed) - before
+
+	// 7% per second scaled by the radiant effect multiplier
+	if math.Abs(gained-0.07*RadiantEffectScale) > 1e-9 {
+		t.Errorf("Expected Radiant Guinsoos to grant %.3f attack speed, got %.3f", 0.07*RadiantEffectScale, gained)
+	}
+}
+
+func TestRadiantNames(t *testing.T) {
+	got := RadiantNames([]string{"IE", "Mittens", "Radiant Red"})
+	want := []string{"Radiant IE", "Mittens", "Radiant Red"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RadiantNames = %v, want %v", got, want)
+	}
+}
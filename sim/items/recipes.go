@@ -0,0 +1,165 @@
+package items
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"tft-sim/models"
+)
+
+// Recipe combines two components into a completed item
+type Recipe struct {
+	Components [2]string
+	Result     string
+}
+
+var recipes = []Recipe{
+	{Components: [2]string{BFSword, SparringGloves}, Result: "IE"},
+	{Components: [2]string{NeedlesslyLarge, SparringGloves}, Result: "JG"},
+	{Components: [2]string{BFSword, BFSword}, Result: "Deathblade"},
+	{Components: [2]string{NeedlesslyLarge, RecurveBow}, Result: "Guinsoos"},
+	{Components: [2]string{RecurveBow, RecurveBow}, Result: "Red"},
+	{Components: [2]string{ChainVest, RecurveBow}, Result: "Titans"},
+	{Components: [2]string{RecurveBow, NegatronCloak}, Result: "Krakens"},
+	{Components: [2]string{SparringGloves, GiantsBelt}, Result: "Strikers"},
+	{Components: [2]string{TearOfTheGoddess, TearOfTheGoddess}, Result: "Blue"},
+	{Components: [2]string{BFSword, TearOfTheGoddess}, Result: "Shojin"},
+	{Components: [2]string{Spatula, BFSword}, Result: "Marksman Emblem"},
+	{Components: [2]string{Spatula, RecurveBow}, Result: "Duelist Emblem"},
+}
+
+// recipeKey orders a component pair so lookups ignore argument order
+func recipeKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "+" + b
+}
+
+// Recipes returns a copy of the recipe table
+func Recipes() []Recipe {
+	out := make([]Recipe, len(recipes))
+	copy(out, recipes)
+	return out
+}
+
+// Combine returns the completed item crafted from two components
+func Combine(a, b string) (string, bool) {
+	key := recipeKey(a, b)
+	for _, r := range recipes {
+		if recipeKey(r.Components[0], r.Components[1]) == key {
+			return r.Result, true
+		}
+	}
+	return "", false
+}
+
+// Loadout is a set of completed items crafted from a component pool
+type Loadout struct {
+	Items     []string
+	Recipes   []Recipe
+	Leftovers []string // Components not used by the loadout
+}
+
+func (l Loadout) String() string {
+	return strings.Join(l.Items, ", ")
+}
+
+// CraftableLoadouts enumerates every distinct loadout of models.MaxItemSlots
+// completed items that can be crafted from the given components and legally
+// held by the unit. Loadouts are deduplicated by item set and sorted by name.
+func CraftableLoadouts(unit *models.Unit, components []string) ([]Loadout, error) {
+	counts := make(map[string]int)
+	for _, name := range components {
+		item, exists := Get(name)
+		if !exists {
+			return nil, fmt.Errorf("unknown component: %s", name)
+		}
+		if item.Category != models.ItemCategoryComponent {
+			return nil, fmt.Errorf("%s is not a component", name)
+		}
+		counts[name]++
+	}
+
+	seen := make(map[string]bool)
+	var loadouts []Loadout
+	var chosen []Recipe
+
+	var search func(start int)
+	search = func(start int) {
+		if len(chosen) == models.MaxItemSlots {
+			loadout := newLoadout(chosen, counts)
+			key := loadout.String()
+			if seen[key] || validateLoadout(unit, loadout.Items) != nil {
+				return
+			}
+			seen[key] = true
+			loadouts = append(loadouts, loadout)
+			return
+		}
+
+		// Recipes are chosen in non-decreasing index order so each
+		// multiset of recipes is only visited once
+		for i := start; i < len(recipes); i++ {
+			a, b := recipes[i].Components[0], recipes[i].Components[1]
+			counts[a]--
+			counts[b]--
+			if counts[a] >= 0 && counts[b] >= 0 {
+				chosen = append(chosen, recipes[i])
+				search(i)
+				chosen = chosen[:len(chosen)-1]
+			}
+			counts[a]++
+			counts[b]++
+		}
+	}
+	search(0)
+
+	sort.Slice(loadouts, func(i, j int) bool {
+		return loadouts[i].String() < loadouts[j].String()
+	})
+	return loadouts, nil
+}
+
+// newLoadout builds a loadout from the chosen recipes; counts holds the
+// components still unused
+func newLoadout(chosen []Recipe, counts map[string]int) Loadout {
+	loadout := Loadout{Recipes: append([]Recipe(nil), chosen...)}
+	for _, r := range chosen {
+		loadout.Items = append(loadout.Items, r.Result)
+	}
+	sort.Strings(loadout.Items)
+
+	for name, count := range counts {
+		for i := 0; i < count; i++ {
+			loadout.Leftovers = append(loadout.Leftovers, name)
+		}
+	}
+	sort.Strings(loadout.Leftovers)
+	return loadout
+}
+
+// validateLoadout checks the items against the unit's item rules without
+// modifying the unit
+func validateLoadout(unit *models.Unit, itemNames []string) error {
+	scratch := &models.Unit{
+		Name:   unit.Name,
+		Traits: append([]string(nil), unit.Traits...),
+		Items:  append([]models.ItemInstance(nil), unit.Items...),
+	}
+
+	for _, name := range itemNames {
+		item, exists := Get(name)
+		if !exists {
+			return fmt.Errorf("recipe result %s is not registered", name)
+		}
+		if err := scratch.CanAddItem(item); err != nil {
+			return err
+		}
+		scratch.Items = append(scratch.Items, models.ItemInstance{Item: item})
+		if item.GrantsTrait != "" {
+			scratch.Traits = append(scratch.Traits, item.GrantsTrait)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package items
+
+import (
+	"testing"
+	"tft-sim/models"
+)
+
+func TestRecipeResultsRegistered(t *testing.T) {
+	for _, r := range Recipes() {
+		for _, name := range r.Components {
+			item, exists := Get(name)
+			if !exists || item.Category != models.ItemCategoryComponent {
+				t.Errorf("Recipe for %s uses unknown component %s", r.Result, name)
+			}
+		}
+		if _, exists := Get(r.Result); !exists {
+			t.Errorf("Recipe result %s not found in registry", r.Result)
+		}
+	}
+}
+
+func TestCombineIgnoresOrder(t *testing.T) {
+	for _, pair := range [][2]string{{BFSword, SparringGloves}, {SparringGloves, BFSword}} {
+		result, ok := Combine(pair[0], pair[1])
+		if !ok || result != "IE" {
+			t.Errorf("Combine(%s, %s) = %q, want IE", pair[0], pair[1], result)
+		}
+	}
+
+	if _, ok := Combine(ChainVest, GiantsBelt); ok {
+		t.Error("Expected no recipe for Chain Vest + Giant's Belt")
+	}
+}
+
+func TestCraftableLoadouts(t *testing.T) {
+	pool := []string{BFSword, SparringGloves, RecurveBow, RecurveBow, ChainVest, NeedlesslyLarge}
+
+	loadouts, err := CraftableLoadouts(newItemTestUnit(), pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loadouts) != 1 || loadouts[0].String() != "Guinsoos, IE, Titans" {
+		t.Fatalf("Unexpected loadouts: %v", loadouts)
+	}
+	if len(loadouts[0].Leftovers) != 0 {
+		t.Errorf("Expected every component to be used, got leftovers %v", loadouts[0].Leftovers)
+	}
+}
+
+func TestCraftableLoadoutsRespectsItemRules(t *testing.T) {
+	// Two IEs can be crafted but IE is unique
+	pool := []string{BFSword, BFSword, SparringGloves, SparringGloves, Spatula, RecurveBow}
+
+	loadouts, err := CraftableLoadouts(newItemTestUnit(), pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range loadouts {
+		if l.String() == "Duelist Emblem, IE, IE" {
+			t.Error("Loadout with two unique IEs should be rejected")
+		}
+	}
+
+	// A Duelist cannot hold a Duelist Emblem
+	loadouts, err = CraftableLoadouts(newItemTestUnit("Duelist"), pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range loadouts {
+		for _, name := range l.Items {
+			if name == "Duelist Emblem" {
+				t.Errorf("Loadout %v gives a Duelist Emblem to a Duelist", l)
+			}
+		}
+	}
+
+	if _, err := CraftableLoadouts(newItemTestUnit(), []string{"IE"}); err == nil {
+		t.Error("Expected error for completed item in component pool")
+	}
+}
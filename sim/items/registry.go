@@ -10,10 +10,17 @@ var (
 	mu       sync.RWMutex
 )
 
+// Register adds an item to the registry. Completed items also register
+// their radiant variant.
 func Register(item models.Item) {
 	mu.Lock()
 	defer mu.Unlock()
 	registry[item.Name] = item
+
+	if item.Category == models.ItemCategoryCompleted {
+		radiant := NewRadiant(item)
+		registry[radiant.Name] = radiant
+	}
 }
 
 func Get(name string) (models.Item, bool) {
@@ -0,0 +1,18 @@
+package items
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Item{
+		Name:        "Shojin",
+		Description: "Attacks grant 5 bonus Mana.",
+		Stats: map[models.StatType]float64{
+			models.StatAttackDamage:  0.15,
+			models.StatAbilityPower:  0.15,
+			models.StatStartingMana:  15,
+			models.StatManaPerAttack: 5,
+		},
+	})
+}
@@ -16,12 +16,10 @@ func init() {
 			models.StatCritChance:  0.20,
 			models.StatDamageAmp:   0.10,
 		},
-		OnHitEffect: func(itemInstance *models.ItemInstance, target *models.Target, damage float64) {
+		OnHitEffect: func(itemInstance *models.ItemInstance, target *models.Target, damage float64, isCrit bool) {
 			unit := itemInstance.Owner
 
-			// Check if the last attack was a critical strike
-			// The CritTracker is updated before OnHitEffect is called
-			if unit.CritTracker != nil && unit.CritTracker.CritStreak > 0 {
+			if isCrit {
 				currentTime := unit.Stats.CurrentTime
 
 				itemIndex := -1
@@ -40,7 +38,7 @@ func init() {
 
 				// Apply DamageAmpBuff with 5% damage amp for 5 seconds, stacking up to 4 times
 				damageAmpBuff := models.NewBuff(buffName, 5*time.Second)
-				damageAmpBuff.AddStatBonus(models.StatDamageAmp, 0.05)
+				damageAmpBuff.AddStatBonus(models.StatDamageAmp, itemInstance.Item.Scaled(0.05))
 				damageAmpBuff.SetStacking(4, models.StackBehaviorAdditive)
 
 				// Apply the buff
@@ -0,0 +1,26 @@
+package items
+
+import (
+	"testing"
+	"tft-sim/models"
+)
+
+func TestStrikersProcsOnTheLandingCrit(t *testing.T) {
+	unit := equip(t, newItemTestUnit(), "Strikers")
+	instance := &unit.Items[0]
+	target := models.NewTarget("Dummy", 1000, 0, 0)
+	const buffName = "Strikers Damage Amp0"
+
+	// A crit rolled by a later attack must not proc this hit
+	unit.CritTracker.CritStreak = 1
+	instance.Item.OnHitEffect(instance, target, 100, false)
+	if unit.BuffManager.HasBuff(buffName, 0) {
+		t.Error("Expected no damage amp from a hit that did not crit")
+	}
+
+	unit.CritTracker.CritStreak = 0
+	instance.Item.OnHitEffect(instance, target, 100, true)
+	if !unit.BuffManager.HasBuff(buffName, 0) {
+		t.Error("Expected damage amp from a hit that crit")
+	}
+}
@@ -13,7 +13,7 @@ func init() {
 			models.StatAttackSpeed: 0.10,
 			models.StatArmor:       20.0,
 		},
-		OnHitEffect: func(itemInstance *models.ItemInstance, target *models.Target, damage float64) {
+		OnHitEffect: func(itemInstance *models.ItemInstance, target *models.Target, damage float64, isCrit bool) {
 			unit := itemInstance.Owner
 			currentTime := unit.Stats.CurrentTime
 
@@ -33,35 +33,37 @@ func init() {
 			// Create unique buff name for this item instance
 			buffName := fmt.Sprintf("Titans Resolve %d", itemIndex)
 
+			// Nothing left to gain once fully stacked
+			if itemInstance.Stacks >= 25 && unit.BuffManager.HasBuff(buffName, currentTime) {
+				return
+			}
+
 			// Create a buff with 1 stack worth of bonuses
 			buff := models.NewBuff(buffName, 0)
 			buff.SetStacking(25, models.StackBehaviorAdditive)
 
 			// Each stack gives 2% AD and 2% AP
-			buff.AddStatBonus(models.StatAttackDamage, 0.02)
-			buff.AddStatBonus(models.StatAbilityPower, 0.02)
+			buff.AddStatBonus(models.StatAttackDamage, itemInstance.Item.Scaled(0.02))
+			buff.AddStatBonus(models.StatAbilityPower, itemInstance.Item.Scaled(0.02))
 
 			// Apply the buff - the buff manager will handle stacking
 			unit.BuffManager.ApplyBuff(buff, currentTime)
 
+			activeBuff := unit.BuffManager.GetBuff(buffName, currentTime)
+			if activeBuff == nil {
+				return
+			}
+
 			// Check if we have 25 stacks and add damage amp
-			activeBuffs := unit.BuffManager.GetActiveBuffs(currentTime)
-			for _, activeBuff := range activeBuffs {
-				if activeBuff.Name == buffName && activeBuff.CurrentStacks >= 25 {
-					// Ensure damage amp is applied (as multiplier)
-					if activeBuff.StatBonuses[models.StatDamageAmp] < 0.10 {
-						activeBuff.AddStatBonus(models.StatDamageAmp, 0.10)
-					}
+			if activeBuff.CurrentStacks >= 25 {
+				ampBonus := itemInstance.Item.Scaled(0.10)
+				if activeBuff.StatBonuses[models.StatDamageAmp] < ampBonus {
+					activeBuff.AddStatBonus(models.StatDamageAmp, ampBonus)
 				}
 			}
 
 			// Update item instance stacks to match buff stacks
-			for _, activeBuff := range activeBuffs {
-				if activeBuff.Name == buffName {
-					itemInstance.Stacks = activeBuff.CurrentStacks
-					break
-				}
-			}
+			itemInstance.Stacks = activeBuff.CurrentStacks
 		},
 		Stacking:  true,
 		MaxStacks: 25,
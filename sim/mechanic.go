@@ -0,0 +1,55 @@
+package sim
+
+import "tft-sim/models"
+
+// SetMechanic is a set's core rule, such as a portal, encounter, anomaly or
+// power-up. Mechanics are given to the simulator through
+// SimulationConfig.Mechanics and keep their own state for one combat, so use
+// a fresh instance per run. Embed BaseMechanic to only implement the hooks a
+// mechanic needs.
+type SetMechanic interface {
+	Name() string
+
+	// ModifyStats returns bonuses added to the unit for the whole combat.
+	// They go to each stat's default bucket like Stats.AddBonus.
+	ModifyStats(unit *models.Unit) map[models.StatType]float64
+
+	OnCombatStart(s *Simulator)
+	OnSecond(s *Simulator)
+	OnAttack(s *Simulator, target *models.Target)  // When an auto attack is released
+	OnCast(s *Simulator, targets []*models.Target) // When an ability cast starts
+	// OnDamage sees every damage instance the unit deals, after overkill is
+	// removed. Damage dealt from it triggers OnDamage again.
+	OnDamage(s *Simulator, target *models.Target, damage float64, damageType models.DamageType, isAbility bool)
+}
+
+// BaseMechanic implements every SetMechanic hook except Name as a no-op
+type BaseMechanic struct{}
+
+func (BaseMechanic) ModifyStats(*models.Unit) map[models.StatType]float64 { return nil }
+
+func (BaseMechanic) OnCombatStart(*Simulator) {}
+
+func (BaseMechanic) OnSecond(*Simulator) {}
+
+func (BaseMechanic) OnAttack(*Simulator, *models.Target) {}
+
+func (BaseMechanic) OnCast(*Simulator, []*models.Target) {}
+
+func (BaseMechanic) OnDamage(*Simulator, *models.Target, float64, models.DamageType, bool) {}
+
+// startMechanics applies each mechanic's stats as a permanent buff named
+// after it, so rerunning the simulator does not stack them, then fires the
+// combat start hooks
+func (s *Simulator) startMechanics() {
+	for _, mechanic := range s.Config.Mechanics {
+		if stats := mechanic.ModifyStats(s.Unit); len(stats) > 0 {
+			buff := models.NewBuff(mechanic.Name(), 0)
+			for stat, value := range stats {
+				buff.AddStatBonus(stat, value)
+			}
+			s.Unit.BuffManager.ApplyBuff(buff, s.Time)
+		}
+		mechanic.OnCombatStart(s)
+	}
+}
@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"testing"
+	"tft-sim/models"
+	"time"
+)
+
+// countingMechanic counts every hook call
+type countingMechanic struct {
+	BaseMechanic
+	counts map[string]int
+}
+
+func (m *countingMechanic) Name() string { return "Counting" }
+
+func (m *countingMechanic) ModifyStats(*models.Unit) map[models.StatType]float64 {
+	return map[models.StatType]float64{models.StatAttackDamage: 0.5}
+}
+
+func (m *countingMechanic) OnCombatStart(*Simulator) { m.counts["combatStart"]++ }
+
+func (m *countingMechanic) OnSecond(*Simulator) { m.counts["second"]++ }
+
+func (m *countingMechanic) OnAttack(*Simulator, *models.Target) { m.counts["attack"]++ }
+
+func (m *countingMechanic) OnCast(*Simulator, []*models.Target) { m.counts["cast"]++ }
+
+func (m *countingMechanic) OnDamage(_ *Simulator, _ *models.Target, _ float64, _ models.DamageType, isAbility bool) {
+	if isAbility {
+		m.counts["abilityDamage"]++
+	} else {
+		m.counts["attackDamage"]++
+	}
+}
+
+func TestSetMechanicHooksFire(t *testing.T) {
+	mechanic := &countingMechanic{counts: make(map[string]int)}
+	unit := newTestUnit()
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 5 * time.Second
+	simulator.Config.Mechanics = []SetMechanic{mechanic}
+	result := simulator.Run()
+
+	if mechanic.counts["combatStart"] != 1 || mechanic.counts["second"] != 4 {
+		t.Errorf("Expected one combat start and 4 seconds, got %v", mechanic.counts)
+	}
+	if mechanic.counts["attack"] != result.AttackCount || mechanic.counts["cast"] != len(result.CastWindows) {
+		t.Errorf("Expected %d attacks and %d casts, got %v", result.AttackCount, len(result.CastWindows), mechanic.counts)
+	}
+	if mechanic.counts["cast"] == 0 || mechanic.counts["abilityDamage"] != mechanic.counts["cast"] {
+		t.Errorf("Expected ability damage from each cast, got %v", mechanic.counts)
+	}
+	if mechanic.counts["attackDamage"] != result.AttackCount {
+		t.Errorf("Expected attack damage from each attack, got %v", mechanic.counts)
+	}
+	if len(result.Mechanics) != 1 || result.Mechanics[0] != "Counting" {
+		t.Errorf("Expected the mechanic in the result, got %v", result.Mechanics)
+	}
+}
+
+func TestSetMechanicStatsDoNotStack(t *testing.T) {
+	mechanic := &countingMechanic{counts: make(map[string]int)}
+	unit := newTestUnit()
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = time.Second
+	simulator.Config.Mechanics = []SetMechanic{mechanic}
+
+	for i := 0; i < 2; i++ {
+		simulator.Run()
+		if got := unit.Stats.Get(models.StatAttackDamage); got != 75 {
+			t.Errorf("Run %d: expected 50%% bonus AD once, got %.1f", i+1, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package mechanics
+
+import (
+	"fmt"
+	"tft-sim/models"
+	"tft-sim/sim"
+)
+
+func init() {
+	Register("Anomaly: Overdrive", func() sim.SetMechanic { return &overdrive{} })
+}
+
+// Overdrive values
+const (
+	overdriveAttackSpeed  = 0.10
+	overdriveAmpPerSecond = 0.02
+	overdriveMaxStacks    = 10
+	overdriveBuff         = "Overdrive"
+)
+
+// overdrive is an anomaly, a modifier given to a single unit: +10% Attack
+// Speed, and every second of combat grants 2% Damage Amp up to 20%. Casting
+// an ability vents the built up Damage Amp.
+type overdrive struct {
+	sim.BaseMechanic
+	stacks int
+}
+
+func (o *overdrive) Name() string {
+	return "Anomaly: Overdrive"
+}
+
+func (o *overdrive) ModifyStats(*models.Unit) map[models.StatType]float64 {
+	return map[models.StatType]float64{models.StatAttackSpeed: overdriveAttackSpeed}
+}
+
+func (o *overdrive) OnCombatStart(*sim.Simulator) {
+	o.stacks = 0
+}
+
+func (o *overdrive) OnSecond(s *sim.Simulator) {
+	if o.stacks >= overdriveMaxStacks {
+		return
+	}
+	o.stacks++
+
+	buff := s.Unit.BuffManager.GetBuff(overdriveBuff, s.Time)
+	if buff == nil {
+		buff = models.NewBuff(overdriveBuff, 0)
+		s.Unit.BuffManager.ApplyBuff(buff, s.Time)
+	}
+	buff.AddStatBonus(models.StatDamageAmp, overdriveAmpPerSecond*float64(o.stacks))
+}
+
+func (o *overdrive) OnCast(s *sim.Simulator, _ []*models.Target) {
+	if o.stacks == 0 {
+		return
+	}
+	if s.Config.Verbose {
+		fmt.Printf("[%.2fs] %s vents %d Overdrive stacks\n", s.Time.Seconds(), s.Unit.Name, o.stacks)
+	}
+	o.stacks = 0
+	s.Unit.BuffManager.RemoveBuff(overdriveBuff)
+}
@@ -0,0 +1,81 @@
+package mechanics
+
+import (
+	"math"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+)
+
+func newMechanicTestUnit(mana float64) *models.Unit {
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 100,
+		models.StatAttackSpeed:  1.0,
+		models.StatMana:         mana,
+	}
+	template := models.Unit{Name: "Test Unit", UnitRole: models.RoleAttackMarksman, StarLevel: 1}
+	return models.NewUnit(template, models.Ability{Name: "Vent", CastTime: 100 * time.Millisecond}, baseStats, 2)
+}
+
+func runMechanicSim(t *testing.T, unit *models.Unit, duration time.Duration, names ...string) *sim.Simulator {
+	t.Helper()
+	setMechanics, err := New(names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 1e9, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = duration
+	simulator.Config.Mechanics = setMechanics
+	simulator.Run()
+	return simulator
+}
+
+func TestRegistryCreatesFreshInstances(t *testing.T) {
+	for _, name := range GetAll() {
+		a, _ := Get(name)
+		b, _ := Get(name)
+		if a == b {
+			t.Errorf("Expected %s to create a new instance per call", name)
+		}
+		if a.Name() != name {
+			t.Errorf("Expected %s to be registered under its name, got %s", a.Name(), name)
+		}
+	}
+
+	if _, err := New([]string{"Missing"}); err == nil {
+		t.Error("Expected an error for an unknown set mechanic")
+	}
+}
+
+func TestOverdriveRampsDamageAmp(t *testing.T) {
+	unit := newMechanicTestUnit(1000)
+	simulator := runMechanicSim(t, unit, 14*time.Second, "Anomaly: Overdrive")
+	unit.Stats.SetCurrentTime(simulator.Time)
+
+	if got := unit.Stats.Get(models.StatAttackSpeed); math.Abs(got-1.1) > 1e-9 {
+		t.Errorf("Expected 10%% bonus attack speed, got %.3f", got)
+	}
+	if got := unit.Stats.Get(models.StatDamageAmp); math.Abs(got-0.20) > 1e-9 {
+		t.Errorf("Expected Damage Amp capped at 20%%, got %.3f", got)
+	}
+}
+
+func TestOverdriveVentsOnCast(t *testing.T) {
+	// 10 mana per attack casts on the fifth attack, before 4 seconds
+	unit := newMechanicTestUnit(50)
+	simulator := runMechanicSim(t, unit, 5500*time.Millisecond, "Anomaly: Overdrive")
+	unit.Stats.SetCurrentTime(simulator.Time)
+
+	if len(simulator.Results.CastWindows) != 1 {
+		t.Fatalf("Expected one cast, got %d", len(simulator.Results.CastWindows))
+	}
+	// Only the seconds since the cast count
+	cast := simulator.Results.CastWindows[0].Start
+	want := 0.02 * float64(int(simulator.Time.Seconds())-int(cast.Seconds()))
+	if got := unit.Stats.Get(models.StatDamageAmp); cast < 3*time.Second || math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected the cast at %v to vent the Damage Amp to %.2f, got %.3f", cast, want, got)
+	}
+}
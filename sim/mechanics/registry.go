@@ -0,0 +1,63 @@
+package mechanics
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"tft-sim/sim"
+)
+
+// MechanicFactory creates a fresh instance of a set mechanic for one combat
+type MechanicFactory func() sim.SetMechanic
+
+var (
+	registry = make(map[string]MechanicFactory)
+	mu       sync.RWMutex
+)
+
+// Register registers a set mechanic factory for the given name
+func Register(name string, factory MechanicFactory) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = factory
+}
+
+// Get creates a new instance of the named set mechanic
+func Get(name string) (sim.SetMechanic, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	factory, exists := registry[name]
+	if !exists {
+		return nil, false
+	}
+
+	return factory(), true
+}
+
+// GetAll returns the sorted names of all registered set mechanics
+func GetAll() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a fresh instance of each named set mechanic, ready for
+// sim.SimulationConfig.Mechanics
+func New(names []string) ([]sim.SetMechanic, error) {
+	mechanics := make([]sim.SetMechanic, 0, len(names))
+	for _, name := range names {
+		mechanic, exists := Get(name)
+		if !exists {
+			return nil, fmt.Errorf("set mechanic %s not found in registry", name)
+		}
+		mechanics = append(mechanics, mechanic)
+	}
+	return mechanics, nil
+}
@@ -3,6 +3,7 @@ package sim
 import (
 	"fmt"
 	"math"
+	"strings"
 	"tft-sim/models"
 	"time"
 )
@@ -12,18 +13,47 @@ type SimulationConfig struct {
 	TickInterval time.Duration
 	Targets      []*models.Target
 	Verbose      bool
+	Timeline     TimelineConfig
+
+	// Seed makes crit rolls reproducible. Zero keeps the unit's time-seeded RNG.
+	Seed int64
+
+	// StartDelay is how long the unit spends before it can attack or cast,
+	// e.g. walking into range. Combat start effects, buffs and per-second
+	// effects still run from the start of combat, and DPS and time to kill
+	// include the delay.
+	StartDelay time.Duration
+
+	// PreCast lets the unit cast its ability during StartDelay when its mana
+	// is already full, like a champion casting on the way into range.
+	// Attacks still wait for the delay.
+	PreCast bool
+
+	// IncomingDPS is damage per second dealt to the simulated unit, used to
+	// exercise damage-taken and health threshold triggers. Zero disables it.
+	IncomingDPS        float64
+	IncomingDamageType models.DamageType
+
+	// RecordEvents fills SimulationResult.Events with state transitions and
+	// the steps of every auto attack
+	RecordEvents bool
+
+	// Mechanics are the set mechanics in play, see SetMechanic
+	Mechanics []SetMechanic
 }
 
 type DamageOverTime struct {
 	Timestamp        time.Duration
 	CumulativeDamage float64
 	InstantDamage    float64
-	DamageByType     map[models.DamageType]float64
+	DamageType       models.DamageType // Type of InstantDamage
 }
 
 type SimulationResult struct {
-	TotalDamage    float64
-	DPS            float64
+	TotalDamage    float64 // Effective damage; damage beyond a target's remaining HP is in OverkillDamage
+	DPS            float64 // TotalDamage per second
+	OverkillDamage float64
+	RawDPS         float64 // TotalDamage plus OverkillDamage per second
 	DamageByType   map[models.DamageType]float64
 	DamageBySource map[string]float64
 	DamageLog      []models.DamageEvent
@@ -34,6 +64,18 @@ type SimulationResult struct {
 	AttackCount    int
 	AbilityCount   int
 	CritRate       float64
+	Timeline       []StatSample
+	CastWindows    []CastWindow
+	Overkill       map[string]float64 // Overkill damage by target name
+	Events         []CombatEvent      // Only recorded with SimulationConfig.RecordEvents
+
+	// WastedAttacks counts missiles whose target died before they arrived,
+	// and WastedDamage is the damage they would have dealt
+	WastedAttacks int
+	WastedDamage  float64
+	ActiveTraits  []string
+	Augments      []string
+	Mechanics     []string
 }
 
 type Simulator struct {
@@ -45,7 +87,9 @@ type Simulator struct {
 	Results    SimulationResult
 	GainedMana float64
 	LastSecond float64
-	ManaLocked bool
+	nextSample time.Duration
+
+	projectiles []attackHit // Released attacks still travelling to their target
 }
 
 func NewSimulator(unit *models.Unit, targets []*models.Target) *Simulator {
@@ -70,15 +114,25 @@ func (s *Simulator) Run() SimulationResult {
 	s.LastSecond = 0
 	s.GainedMana = 0
 	s.IsRunning = true
-	s.ManaLocked = false
+	s.nextSample = 0
 	s.Unit.AttackTimer = 0
+	s.Unit.Attack = models.AttackContext{}
+	s.projectiles = s.projectiles[:0]
+	if s.Config.Seed != 0 {
+		s.Unit.CritTracker.Seed(s.Config.Seed)
+	}
 
 	// Initialize kill tracking
 	for _, target := range s.Targets {
 		s.Results.TimeToKill[target.Name] = -1
 	}
 
+	s.startCombat()
+
 	for s.Time < s.Config.Duration && s.IsRunning {
+		// Sample with buff stats at this tick's time
+		s.Unit.Stats.SetCurrentTime(s.Time)
+		s.sampleTimeline()
 		s.tick()
 		s.Time += s.Config.TickInterval
 
@@ -96,6 +150,10 @@ func (s *Simulator) Run() SimulationResult {
 		}
 	}
 
+	// Close a cast still in progress when the fight ended
+	s.closeCastWindow()
+	s.dropProjectiles()
+
 	// Calculate final results
 	s.calculateResults()
 
@@ -111,10 +169,29 @@ func (s *Simulator) tick() {
 		s.Unit.BuffManager.UpdateBuffs(s.Time)
 	}
 
+	// Take incoming damage from the enemy team
+	s.applyIncomingDamage()
+	if !s.IsRunning {
+		return
+	}
+
+	// Attacks already released land even while the unit is busy
+	s.landProjectiles()
+
+	// Still moving into range
+	if s.Time < s.Config.StartDelay {
+		if s.Config.PreCast {
+			s.preCast()
+		}
+		s.onSecond()
+		return
+	}
+
 	// 1. Handle ongoing casts
 	if s.Unit.State == models.UnitStateCasting && s.Unit.CastingCtx != nil {
 		if s.Time >= s.Unit.CastingCtx.EndTime {
 			s.Unit.CompleteCast(s.Time)
+			s.closeCastWindow()
 		} else if !s.Unit.Ability.AllowsAutoAttacksDuringCast {
 			// Still casting, check for mana gain
 			s.onSecond()
@@ -122,48 +199,92 @@ func (s *Simulator) tick() {
 		}
 	}
 
-	// 2. Check for ability cast
+	// 2. Check for ability cast. Casting takes priority over an attack still
+	// winding up, unless the ability lets the unit keep attacking.
 	if s.Unit.CanCastAbility() && s.Unit.CurrentMana >= s.Unit.Stats.Get(models.StatMana) {
 		targets := s.findAbilityTargets()
 		if len(targets) > 0 {
+			if s.Unit.IsWindingUp() && !s.Unit.Ability.AllowsAutoAttacksDuringCast {
+				s.cancelAttack("casting " + s.Unit.Ability.Name)
+			}
 			s.startAbilityCast(targets)
 			return // Started casting, wait for next tick
 		}
 	}
 
-	// 3. Check for auto attack
+	// 3. Start an auto attack windup when the attack timer is ready and
+	// release it once the windup is over
 	if s.Unit.CanAutoAttack(s.Time) {
-		s.performAutoAttack()
-		s.Unit.NextAttackTime = s.Time + s.Unit.GetAttackInterval()
+		s.startAttack()
+	}
+	if s.Unit.IsWindingUp() && s.Time >= s.Unit.Attack.ReleaseTime {
+		if s.Unit.Attack.Target.IsDead() {
+			s.cancelAttack("target died")
+		} else {
+			s.releaseAttack()
+		}
 	}
 
 	// 4. New Second
 	s.onSecond()
 }
 
+// preCast starts and completes ability casts while the unit is still moving
+// into range, see SimulationConfig.PreCast. A cast still running when the
+// delay ends carries on in tick.
+func (s *Simulator) preCast() {
+	if s.Unit.State == models.UnitStateCasting && s.Unit.CastingCtx != nil {
+		if s.Time >= s.Unit.CastingCtx.EndTime {
+			s.Unit.CompleteCast(s.Time)
+			s.closeCastWindow()
+		}
+		return
+	}
+
+	if s.Unit.CanCastAbility() && s.Unit.CurrentMana >= s.Unit.Stats.Get(models.StatMana) {
+		if targets := s.findAbilityTargets(); len(targets) > 0 {
+			s.startAbilityCast(targets)
+		}
+	}
+}
+
 func (s *Simulator) onSecond() {
 	if int(s.Time.Seconds()) <= int(math.Floor(s.LastSecond)) {
 		return
 	}
 
 	// Gain Mana
-	if s.Unit.CastingCtx != nil && s.Unit.CastingCtx.CanGainMana {
-		manaRegen := s.Unit.Stats.Get(models.StatManaRegen)
-		s.Unit.CurrentMana += manaRegen
-	}
+	s.Unit.RegenMana(s.Time)
 
 	// Second Effects
-	for _, item := range s.Unit.Items {
+	s.Unit.ForEachItem(func(item *models.ItemInstance) {
 		if item.Item.OnSecondEffect != nil {
-			item.Item.OnSecondEffect(&item)
+			item.Item.OnSecondEffect(item)
+		}
+	})
+	for _, augment := range s.Unit.Augments {
+		if augment.OnSecondEffect != nil {
+			augment.OnSecondEffect(s.Unit)
+		}
+	}
+	for _, trait := range s.Unit.ActiveTraits {
+		if trait.Breakpoint.OnSecondEffect != nil {
+			trait.Breakpoint.OnSecondEffect(s.Unit)
 		}
 	}
+	for _, mechanic := range s.Config.Mechanics {
+		mechanic.OnSecond(s)
+	}
 
 	s.LastSecond = s.Time.Seconds()
 }
 
 func (s *Simulator) startAbilityCast(targets []*models.Target) {
 	s.Unit.StartCastingAbility(s.Time, targets)
+	s.openCastWindow()
+	for _, mechanic := range s.Config.Mechanics {
+		mechanic.OnCast(s, targets)
+	}
 
 	if s.Config.Verbose {
 		fmt.Printf("[%.2fs] %s starts casting %s (cost: %.0f mana)\n",
@@ -171,12 +292,12 @@ func (s *Simulator) startAbilityCast(targets []*models.Target) {
 	}
 }
 
-func (s *Simulator) performAutoAttack() {
-	// Find alive target
-	target := s.findTarget()
-	if target == nil {
-		return
-	}
+// releaseAttack fires the attack that finished its windup. Damage, crit and
+// on-attack effects are decided now; the damage itself lands at once for
+// melee units or when the projectile arrives for ranged units.
+func (s *Simulator) releaseAttack() {
+	target := s.Unit.ReleaseAttack()
+	s.recordEvent(CombatEvent{Type: EventAttackRelease, Target: target.Name})
 
 	isOverride := false
 
@@ -184,14 +305,13 @@ func (s *Simulator) performAutoAttack() {
 	var damageType models.DamageType = models.DamageTypePhysical
 	// Check for buffs that modify auto attacks
 	if s.Unit.BuffManager != nil {
-		activeBuffs := s.Unit.BuffManager.GetActiveBuffs(s.Time)
-		for _, buff := range activeBuffs {
+		s.Unit.BuffManager.ForEachActiveBuff(s.Time, func(buff *models.Buff) {
 			if buff.ModifiesAutoAttack && buff.AutoAttackOverride != nil {
 				// Use buff's auto attack override
 				physDmg = buff.AutoAttackOverride(s.Unit, target)
 				isOverride = true
 			}
-		}
+		})
 	}
 
 	canCrit := true
@@ -199,98 +319,193 @@ func (s *Simulator) performAutoAttack() {
 		canCrit = s.Unit.Ability.CanAbilityCrit
 	}
 	physResult, isCrit := models.CalculateDamage(s.Unit, target, target.Stats.Get(models.StatArmor), physDmg, canCrit)
-	// Apply on-hit effects before damage
-	for _, item := range s.Unit.Items {
+	// Apply on-attack effects before damage
+	s.Unit.ForEachItem(func(item *models.ItemInstance) {
 		if item.Item.OnAttackEffect != nil {
-			item.Item.OnAttackEffect(&item)
+			item.Item.OnAttackEffect(item)
+		}
+	})
+	for _, augment := range s.Unit.Augments {
+		if augment.OnAttackEffect != nil {
+			augment.OnAttackEffect(s.Unit)
 		}
 	}
+	for _, mechanic := range s.Config.Mechanics {
+		mechanic.OnAttack(s, target)
+	}
 
-	// Apply damage
-	actualDamage := target.TakeDamage(physResult, damageType)
+	s.Unit.AttackCount++
 
-	// Log damage
-	event := models.DamageEvent{
-		Timestamp:  s.Time,
-		Damage:     actualDamage,
-		DamageType: damageType,
-		IsAbility:  false,
-		IsCrit:     isCrit,
-		TargetName: target.Name,
+	hit := attackHit{target: target, damage: physResult, damageType: damageType, isCrit: isCrit}
+	if travel := s.Unit.MissileTravel(target); travel > 0 {
+		hit.landTime = s.Time + travel
+		s.projectiles = append(s.projectiles, hit)
+		return
 	}
-	s.Unit.DamageLog = append(s.Unit.DamageLog, event)
-	s.Unit.TotalDamage += actualDamage
-	s.Unit.AttackCount++
+	s.landAttack(hit)
+}
+
+// landAttack deals a released attack's damage on impact, then fires on-hit
+// effects and grants the attack's mana
+func (s *Simulator) landAttack(hit attackHit) {
+	target, isCrit := hit.target, hit.isCrit
+	actualDamage := s.applyDamage(target, hit.damage, hit.damageType, false, isCrit)
+	s.recordEvent(CombatEvent{Type: EventAttackLand, Target: target.Name})
 
 	// Apply on-hit effects after damage
-	for _, item := range s.Unit.Items {
+	s.Unit.ForEachItem(func(item *models.ItemInstance) {
 		if item.Item.OnHitEffect != nil {
-			item.Item.OnHitEffect(&item, target, actualDamage)
+			item.Item.OnHitEffect(item, target, actualDamage, isCrit)
+		}
+	})
+	for _, augment := range s.Unit.Augments {
+		if augment.OnHitEffect != nil {
+			augment.OnHitEffect(s.Unit, target, actualDamage)
 		}
 	}
 
-	// Apply on-hit effects after damage
-	for _, buff := range s.Unit.BuffManager.GetActiveBuffs(s.Time) {
+	// Apply trait on-hit effects
+	for _, trait := range s.Unit.ActiveTraits {
+		if trait.Breakpoint.OnHitEffect != nil {
+			trait.Breakpoint.OnHitEffect(s.Unit, target, actualDamage)
+		}
+	}
+
+	// Apply buff on-hit bonus damage
+	s.Unit.BuffManager.ForEachActiveBuff(s.Time, func(buff *models.Buff) {
 		if buff.OnHitEffect != nil {
 			dmg, dmgType, crit := buff.OnHitEffect(s.Unit, target, actualDamage, isCrit)
 			if dmg > 0 {
-				// Log damage
-				event := models.DamageEvent{
-					Timestamp:  s.Time,
-					Damage:     dmg,
-					DamageType: dmgType,
-					IsAbility:  false,
-					IsCrit:     crit,
-					TargetName: target.Name,
-				}
-				s.Unit.DamageLog = append(s.Unit.DamageLog, event)
-				s.Unit.TotalDamage += dmg
-
-				if s.Config.Verbose {
-					critStr := ""
-					if crit {
-						critStr = " CRIT!"
-					}
-					var damageTypeStr string
-					switch dmgType {
-					case models.DamageTypePhysical:
-						damageTypeStr = "physical"
-					case models.DamageTypeMagic:
-						damageTypeStr = "magic"
-					case models.DamageTypeTrue:
-						damageTypeStr = "true"
-					}
-					fmt.Printf("[%.2fs] %s auto attacks %s for %.1f %s%s damage (%.1f HP remaining)\n",
-						s.Time.Seconds(), s.Unit.Name, target.Name, dmg, damageTypeStr, critStr, target.CurrentHP)
-				}
+				s.applyDamage(target, dmg, dmgType, false, crit)
 			}
-
 		}
-	}
+	})
 
 	// Gain mana from auto attack
 	s.Unit.GainMana(true, 0)
+}
+
+// applyDamage applies damage from the unit to a target, logs it and fires the
+// damage-dealt, crit and kill item triggers. It is installed as the unit's
+// DamageHandler so ability damage goes through the same path. It returns the
+// effective damage, which is also all the triggers see.
+func (s *Simulator) applyDamage(target *models.Target, damage float64, damageType models.DamageType, isAbility, isCrit bool) float64 {
+	wasAlive := !target.IsDead()
+	actualDamage, overkill := target.TakeDamage(damage, damageType)
 
-	// Record kill time
-	if target.IsDead() && s.Results.TimeToKill[target.Name] == -1 {
-		s.Results.TimeToKill[target.Name] = s.Time
+	// Log damage
+	event := models.DamageEvent{
+		Timestamp:  s.Time,
+		Damage:     actualDamage,
+		Overkill:   overkill,
+		DamageType: damageType,
+		IsAbility:  isAbility,
+		IsCrit:     isCrit,
+		TargetName: target.Name,
 	}
+	s.Unit.DamageLog = append(s.Unit.DamageLog, event)
+	s.Unit.TotalDamage += actualDamage
+	s.Unit.OverkillDamage += overkill
 
 	if s.Config.Verbose {
 		critStr := ""
 		if isCrit {
 			critStr = " CRIT!"
 		}
-		damageTypeStr := "physical"
-		switch damageType {
-		case models.DamageTypeMagic:
-			damageTypeStr = "magic"
-		case models.DamageTypeTrue:
-			damageTypeStr = "true"
+		if overkill > 0 {
+			critStr += fmt.Sprintf(" (%.1f overkill)", overkill)
 		}
-		fmt.Printf("[%.2fs] %s auto attacks %s for %.1f %s%s damage (%.1f HP remaining)\n",
-			s.Time.Seconds(), s.Unit.Name, target.Name, actualDamage, damageTypeStr, critStr, target.CurrentHP)
+		action := "auto attacks"
+		if isAbility {
+			action = "hits with " + s.Unit.Ability.Name + " on"
+		}
+		fmt.Printf("[%.2fs] %s %s %s for %.1f %s%s damage (%.1f HP remaining)\n",
+			s.Time.Seconds(), s.Unit.Name, action, target.Name, actualDamage,
+			strings.ToLower(damageType.String()), critStr, target.CurrentHP)
 	}
+
+	s.Unit.ForEachItem(func(item *models.ItemInstance) {
+		if item.Item.OnDamageDealtEffect != nil {
+			item.Item.OnDamageDealtEffect(item, target, actualDamage, damageType)
+		}
+	})
+
+	for _, mechanic := range s.Config.Mechanics {
+		mechanic.OnDamage(s, target, actualDamage, damageType, isAbility)
+	}
+
+	if isCrit {
+		s.Unit.ForEachItem(func(item *models.ItemInstance) {
+			if item.Item.OnCritEffect != nil {
+				item.Item.OnCritEffect(item, target, actualDamage)
+			}
+		})
+	}
+
+	if wasAlive && target.IsDead() {
+		// Record kill time
+		if s.Results.TimeToKill[target.Name] == -1 {
+			s.Results.TimeToKill[target.Name] = s.Time
+		}
+
+		s.Unit.ForEachItem(func(item *models.ItemInstance) {
+			if item.Item.OnKillEffect != nil {
+				item.Item.OnKillEffect(item, target)
+			}
+		})
+	}
+
+	return actualDamage
+}
+
+// applyIncomingDamage deals the configured incoming damage for one tick to the unit
+func (s *Simulator) applyIncomingDamage() {
+	if s.Config.IncomingDPS <= 0 {
+		return
+	}
+
+	damage := s.Config.IncomingDPS * s.Config.TickInterval.Seconds()
+	s.Unit.TakeDamage(damage, s.Config.IncomingDamageType)
+
+	if s.Unit.IsDead() {
+		if s.Config.Verbose {
+			fmt.Printf("[%.2fs] %s has died\n", s.Time.Seconds(), s.Unit.Name)
+		}
+		s.IsRunning = false
+	}
+}
+
+// startCombat resets per-combat unit state and fires combat start triggers
+func (s *Simulator) startCombat() {
+	s.Unit.Stats.SetCurrentTime(0)
+	s.Unit.Verbose = s.Config.Verbose
+	s.Unit.CurrentHealth = s.Unit.Stats.Get(models.StatHealth)
+	s.Unit.Shield = 0
+	s.Unit.ResetMana()
+	s.Unit.SetDamageHandler(s.applyDamage)
+	s.Unit.SetStateHandler(s.onStateChange)
+
+	s.Unit.ForEachItem(func(item *models.ItemInstance) {
+		item.ThresholdTriggered = false
+		if item.Item.OnCombatStartEffect != nil {
+			item.Item.OnCombatStartEffect(item)
+		}
+	})
+
+	for _, augment := range s.Unit.Augments {
+		if augment.OnCombatStartEffect != nil {
+			augment.OnCombatStartEffect(s.Unit)
+		}
+	}
+
+	// Apply combat start buffs from active traits
+	for _, trait := range s.Unit.ActiveTraits {
+		for _, newBuff := range trait.Breakpoint.Buffs {
+			s.Unit.BuffManager.ApplyBuff(newBuff(), s.Time)
+		}
+	}
+
+	s.startMechanics()
 }
 
 func (s *Simulator) findTarget() *models.Target {
@@ -324,7 +539,9 @@ func (s *Simulator) findAbilityTargets() []*models.Target {
 
 func (s *Simulator) calculateResults() {
 	s.Results.TotalDamage = s.Unit.TotalDamage
+	s.Results.OverkillDamage = s.Unit.OverkillDamage
 	s.Results.DPS = s.Unit.TotalDamage / s.Time.Seconds()
+	s.Results.RawDPS = (s.Unit.TotalDamage + s.Unit.OverkillDamage) / s.Time.Seconds()
 	s.Results.DamageLog = s.Unit.DamageLog
 	s.Results.AttackCount = s.Unit.AttackCount
 	s.Results.AbilityCount = s.Unit.AbilityCount
@@ -332,6 +549,7 @@ func (s *Simulator) calculateResults() {
 	// Initialize maps
 	s.Results.DamageByType = make(map[models.DamageType]float64)
 	s.Results.DamageBySource = make(map[string]float64)
+	s.Results.Overkill = make(map[string]float64)
 
 	// Calculate damage by type, source, and damage over time
 	var cumulativeDamage float64
@@ -346,7 +564,7 @@ func (s *Simulator) calculateResults() {
 			Timestamp:        event.Timestamp,
 			CumulativeDamage: cumulativeDamage,
 			InstantDamage:    event.Damage,
-			DamageByType:     map[models.DamageType]float64{event.DamageType: event.Damage},
+			DamageType:       event.DamageType,
 		}
 		s.Results.DamageOverTime = append(s.Results.DamageOverTime, dot)
 
@@ -359,6 +577,10 @@ func (s *Simulator) calculateResults() {
 			sourceType = "Ability"
 		}
 		s.Results.DamageBySource[sourceType] += event.Damage
+
+		if event.Overkill > 0 {
+			s.Results.Overkill[event.TargetName] += event.Overkill
+		}
 	}
 
 	// Calculate crit rate
@@ -366,6 +588,24 @@ func (s *Simulator) calculateResults() {
 		s.Results.CritRate = float64(s.Unit.CritTracker.TotalCrits) / float64(s.Unit.CritTracker.TotalAttacks)
 	}
 
+	// Record active traits
+	s.Results.ActiveTraits = make([]string, len(s.Unit.ActiveTraits))
+	for i, trait := range s.Unit.ActiveTraits {
+		s.Results.ActiveTraits[i] = trait.String()
+	}
+
+	// Record augments
+	s.Results.Augments = make([]string, len(s.Unit.Augments))
+	for i, augment := range s.Unit.Augments {
+		s.Results.Augments[i] = augment.Name
+	}
+
+	// Record set mechanics
+	s.Results.Mechanics = make([]string, len(s.Config.Mechanics))
+	for i, mechanic := range s.Config.Mechanics {
+		s.Results.Mechanics[i] = mechanic.Name()
+	}
+
 	// Record final health
 	for _, target := range s.Targets {
 		s.Results.FinalHealth[target.Name] = target.CurrentHP
@@ -375,8 +615,10 @@ func (s *Simulator) calculateResults() {
 	s.Results.Stats = map[string]interface{}{
 		"FinalMana":      s.Unit.CurrentMana,
 		"MaxManaReached": s.Unit.Stats.Get(models.StatMana),
+		"StartingMana":   s.Unit.Stats.Get(models.StatStartingMana),
 		"AttackSpeed":    s.Unit.GetAttackSpeed(),
 		"AD":             s.Unit.Stats.Get(models.StatAttackDamage),
+		"FinalHealth":    s.Unit.CurrentHealth,
 		"AP":             s.Unit.Stats.Get(models.StatAbilityPower),
 	}
 }
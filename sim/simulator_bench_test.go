@@ -0,0 +1,56 @@
+package sim
+
+import (
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim/items"
+	"tft-sim/sim/units"
+)
+
+// newBenchSimulator sets up a fresh 30 second fight for the given build
+func newBenchSimulator(b *testing.B, itemNames ...string) *Simulator {
+	unit, exists := units.Get("Yunara", 2)
+	if !exists {
+		b.Fatal("Yunara unit not found in registry")
+	}
+	for _, name := range itemNames {
+		item, exists := items.Get(name)
+		if !exists {
+			b.Fatalf("item %s not found in registry", name)
+		}
+		if err := unit.AddItem(item); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Frontline Tank", 50000, 100, 50)})
+	simulator.Config.Verbose = false
+	return simulator
+}
+
+func benchmarkRun(b *testing.B, runs int, itemNames ...string) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for r := 0; r < runs; r++ {
+			newBenchSimulator(b, itemNames...).Run()
+		}
+	}
+	b.ReportMetric(float64(b.N*runs)/b.Elapsed().Seconds(), "fights/s")
+}
+
+// BenchmarkRun is a single 30 second fight including unit setup
+func BenchmarkRun(b *testing.B) {
+	benchmarkRun(b, 1, "Guinsoos", "Titans", "IE")
+}
+
+// BenchmarkRunBatch runs 1000 fights per iteration, as a sweep or Monte
+// Carlo workload would
+func BenchmarkRunBatch(b *testing.B) {
+	benchmarkRun(b, 1000, "Guinsoos", "Titans", "IE")
+}
+
+// BenchmarkRunHighAttackSpeed stacks attack speed items so the unit
+// attacks close to the cap
+func BenchmarkRunHighAttackSpeed(b *testing.B) {
+	benchmarkRun(b, 1, "Red", "Guinsoos", "Krakens")
+}
@@ -0,0 +1,438 @@
+package sim
+
+import (
+	"testing"
+	"tft-sim/models"
+	"time"
+)
+
+// newTestUnit creates a simple attacker that always crits and casts a
+// single-target magic nuke through Unit.DealDamage
+func newTestUnit() *models.Unit {
+	ability := models.Ability{
+		Name:     "Test Nuke",
+		CastTime: 500 * time.Millisecond,
+		OnCast: func(u *models.Unit, targets []*models.Target) {
+			for _, target := range targets {
+				u.DealDamage(target, 100, models.DamageTypeMagic, true, false)
+			}
+		},
+	}
+
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 50,
+		models.StatAttackSpeed:  1.0,
+		models.StatMana:         30,
+		models.StatCritChance:   1.0,
+		models.StatCritDamage:   0.4,
+	}
+
+	return models.NewUnit(models.Unit{Name: "Test Unit", UnitRole: models.RoleAttackMarksman, StarLevel: 1}, ability, baseStats, 2)
+}
+
+func TestItemTriggersFire(t *testing.T) {
+	counts := make(map[string]int)
+	count := func(name string) { counts[name]++ }
+
+	item := models.Item{
+		Name:                  "Trigger Counter",
+		OnCombatStartEffect:   func(*models.ItemInstance) { count("combatStart") },
+		OnAttackEffect:        func(*models.ItemInstance) { count("attack") },
+		OnHitEffect:           func(*models.ItemInstance, *models.Target, float64, bool) { count("hit") },
+		OnDamageDealtEffect:   func(*models.ItemInstance, *models.Target, float64, models.DamageType) { count("damageDealt") },
+		OnCritEffect:          func(*models.ItemInstance, *models.Target, float64) { count("crit") },
+		OnKillEffect:          func(*models.ItemInstance, *models.Target) { count("kill") },
+		OnAbilityCast:         func(*models.ItemInstance) { count("abilityCast") },
+		OnAbilityCastComplete: func(*models.ItemInstance) { count("abilityCastComplete") },
+		OnManaFullEffect:      func(*models.ItemInstance) { count("manaFull") },
+		OnDamageTakenEffect:   func(*models.ItemInstance, float64, models.DamageType) { count("damageTaken") },
+		OnSecondEffect:        func(*models.ItemInstance) { count("second") },
+		OnHealthThresholdEffect: func(instance *models.ItemInstance) {
+			count("healthThreshold")
+			instance.Stacks = 1
+		},
+		HealthThreshold: 0.5,
+	}
+
+	unit := newTestUnit()
+	if err := unit.AddItem(item); err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []*models.Target{models.NewTarget("Dummy", 600, 0, 0)}
+	simulator := NewSimulator(unit, targets)
+	simulator.Config.Verbose = false
+	simulator.Config.IncomingDPS = 100
+	simulator.Config.IncomingDamageType = models.DamageTypeTrue
+	results := simulator.Run()
+
+	for _, trigger := range []string{"attack", "hit", "damageDealt", "crit", "abilityCast", "abilityCastComplete", "manaFull", "damageTaken", "second"} {
+		if counts[trigger] == 0 {
+			t.Errorf("Expected %s trigger to fire", trigger)
+		}
+	}
+
+	for _, trigger := range []string{"combatStart", "kill", "healthThreshold"} {
+		if counts[trigger] != 1 {
+			t.Errorf("Expected %s trigger to fire once, fired %d times", trigger, counts[trigger])
+		}
+	}
+
+	if counts["attack"] != counts["hit"] {
+		t.Errorf("Expected one hit per attack, got %d attacks and %d hits", counts["attack"], counts["hit"])
+	}
+
+	if unit.Items[0].Stacks != 1 {
+		t.Error("Expected trigger to update the equipped item instance")
+	}
+
+	if results.DamageBySource["Ability"] == 0 {
+		t.Error("Expected ability damage dealt through DealDamage to be logged")
+	}
+
+	if results.TimeToKill["Dummy"] < 0 {
+		t.Error("Expected target to be killed")
+	}
+}
+
+func TestIncomingDamageKillsUnit(t *testing.T) {
+	unit := newTestUnit()
+	targets := []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)}
+
+	simulator := NewSimulator(unit, targets)
+	simulator.Config.Verbose = false
+	simulator.Config.IncomingDPS = 500
+	simulator.Config.IncomingDamageType = models.DamageTypeTrue
+	simulator.Run()
+
+	if !unit.IsDead() {
+		t.Fatal("Expected unit to die from incoming damage")
+	}
+
+	if simulator.Time > 3*time.Second {
+		t.Errorf("Expected combat to end when the unit died at ~2s, ended at %v", simulator.Time)
+	}
+}
+
+func TestStartDelayHoldsBackFirstAction(t *testing.T) {
+	var combatStart, secondTicks int
+	item := models.Item{
+		Name:                "Timer",
+		OnCombatStartEffect: func(*models.ItemInstance) { combatStart++ },
+		OnSecondEffect:      func(*models.ItemInstance) { secondTicks++ },
+	}
+
+	unit := newTestUnit()
+	if err := unit.AddItem(item); err != nil {
+		t.Fatal(err)
+	}
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 5 * time.Second
+	simulator.Config.StartDelay = 2 * time.Second
+	result := simulator.Run()
+
+	if len(result.DamageLog) == 0 {
+		t.Fatal("Expected damage after the delay")
+	}
+	latest := 2*time.Second + unit.AttackWindup + simulator.Config.TickInterval
+	if first := result.DamageLog[0].Timestamp; first < 2*time.Second || first > latest {
+		t.Errorf("Expected the first hit one windup after the 2s delay, got %v", first)
+	}
+	if combatStart != 1 || secondTicks < 4 {
+		t.Errorf("Expected combat start and per-second effects to run during the delay, got %d and %d", combatStart, secondTicks)
+	}
+}
+
+func TestPreCastDuringStartDelay(t *testing.T) {
+	for _, preCast := range []bool{false, true} {
+		unit := newTestUnit()
+		unit.Stats.AddBonus(models.StatStartingMana, 30) // Full mana at the start
+
+		simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+		simulator.Config.Verbose = false
+		simulator.Config.Duration = 3 * time.Second
+		simulator.Config.StartDelay = 2 * time.Second
+		simulator.Config.PreCast = preCast
+		simulator.Config.RecordEvents = true
+		result := simulator.Run()
+
+		if len(result.CastWindows) == 0 {
+			t.Fatalf("PreCast %v: expected a cast", preCast)
+		}
+		if first := result.CastWindows[0].Start; (first < 2*time.Second) != preCast {
+			t.Errorf("PreCast %v: first cast started at %v", preCast, first)
+		}
+		for _, e := range eventsOfType(result.Events, EventAttackStart) {
+			if e.Timestamp < 2*time.Second {
+				t.Errorf("PreCast %v: attack started during the delay at %v", preCast, e.Timestamp)
+			}
+		}
+	}
+}
+
+// eventsOfType returns the events of one type in order
+func eventsOfType(events []CombatEvent, eventType EventType) []CombatEvent {
+	var matching []CombatEvent
+	for _, e := range events {
+		if e.Type == eventType {
+			matching = append(matching, e)
+		}
+	}
+	return matching
+}
+
+func TestAttackLandsAfterWindupAndTravel(t *testing.T) {
+	unit := newTestUnit()
+	unit.Stats.SetBase(models.StatMana, 1000) // Never cast
+	unit.AttackRange = 3
+	unit.MissileSpeed = 10
+	travelTime := 300 * time.Millisecond
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 2 * time.Second
+	simulator.Config.RecordEvents = true
+	result := simulator.Run()
+
+	starts := eventsOfType(result.Events, EventAttackStart)
+	releases := eventsOfType(result.Events, EventAttackRelease)
+	lands := eventsOfType(result.Events, EventAttackLand)
+	if len(starts) != 2 || len(releases) != 2 || len(lands) != 2 {
+		t.Fatalf("Expected 2 attacks to start, release and land in 2s, got %d, %d and %d", len(starts), len(releases), len(lands))
+	}
+
+	tick := simulator.Config.TickInterval
+	windup := releases[0].Timestamp - starts[0].Timestamp
+	if windup < unit.AttackWindup || windup > unit.AttackWindup+tick {
+		t.Errorf("Expected release one windup after start, got %v", windup)
+	}
+	travel := lands[0].Timestamp - releases[0].Timestamp
+	if travel < travelTime || travel > travelTime+tick {
+		t.Errorf("Expected landing one travel time after release, got %v", travel)
+	}
+	if result.DamageLog[0].Timestamp != lands[0].Timestamp {
+		t.Errorf("Expected damage when the attack lands, got %v vs %v", result.DamageLog[0].Timestamp, lands[0].Timestamp)
+	}
+
+	changes := eventsOfType(result.Events, EventStateChange)
+	if len(changes) < 2 || changes[0].To != models.UnitStateAttacking || changes[1].To != models.UnitStateIdle {
+		t.Errorf("Expected windup and release state changes, got %v", changes)
+	}
+}
+
+func TestCastCancelsAttackWindup(t *testing.T) {
+	unit := newTestUnit()
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 500 * time.Millisecond
+	simulator.Config.RecordEvents = true
+
+	// Mana fills during the first windup
+	unit.Ability.OnCastStart = nil
+	unit.CurrentMana = 0
+	unit.BuffManager.ApplyBuff(models.NewBuff("Mana Fill", 0).SetCallbacks(nil, func(u *models.Unit) {
+		if u.IsWindingUp() {
+			u.CurrentMana = u.Stats.Get(models.StatMana)
+		}
+	}, nil, nil, nil), 0)
+	result := simulator.Run()
+
+	cancels := eventsOfType(result.Events, EventAttackCancel)
+	if len(cancels) != 1 {
+		t.Fatalf("Expected the cast to cancel the windup, got events %v", result.Events)
+	}
+	if len(result.CastWindows) != 1 || result.AttackCount != 0 {
+		t.Errorf("Expected a cast and no attacks, got %d casts and %d attacks", len(result.CastWindows), result.AttackCount)
+	}
+	if unit.NextAttackTime > cancels[0].Timestamp {
+		t.Errorf("Expected the cancelled attack's timer to be refunded, next attack at %v", unit.NextAttackTime)
+	}
+}
+
+func TestProjectileMissesDeadTarget(t *testing.T) {
+	unit := newTestUnit()
+	unit.Stats.SetBase(models.StatMana, 1000)
+	unit.Stats.SetBase(models.StatAttackSpeed, 5)
+	unit.AttackWindup = 0
+	unit.AttackRange = 4
+	unit.MissileSpeed = 4
+
+	first := models.NewTarget("First", 1, 0, 0)
+	simulator := NewSimulator(unit, []*models.Target{first, models.NewTarget("Second", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 3 * time.Second
+	simulator.Config.RecordEvents = true
+	result := simulator.Run()
+
+	misses := eventsOfType(result.Events, EventProjectileMiss)
+	if len(misses) == 0 {
+		t.Fatal("Expected projectiles in flight to miss the dead target")
+	}
+	if result.WastedAttacks != len(misses) || result.WastedDamage <= 0 {
+		t.Errorf("Expected %d wasted attacks with damage, got %d and %.1f", len(misses), result.WastedAttacks, result.WastedDamage)
+	}
+	for _, miss := range misses {
+		if miss.Target != first.Name {
+			t.Errorf("Expected misses on %s, got %s", first.Name, miss.Target)
+		}
+	}
+	if result.TimeToKill[first.Name] < time.Second {
+		t.Errorf("Expected the first target to die when the first projectile landed, got %v", result.TimeToKill[first.Name])
+	}
+}
+
+func TestProjectilesInFlightWastedWhenLastTargetDies(t *testing.T) {
+	unit := newTestUnit()
+	unit.Stats.SetBase(models.StatMana, 1000)
+	unit.Stats.SetBase(models.StatAttackSpeed, 5)
+	unit.AttackWindup = 0
+	unit.AttackRange = 4
+	unit.MissileSpeed = 4 // One second in flight
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Only", 1, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 3 * time.Second
+	simulator.Config.RecordEvents = true
+	result := simulator.Run()
+
+	// The first projectile kills the target and ends the fight with the
+	// following ones still in flight
+	if result.AttackCount < 2 {
+		t.Fatalf("Expected several attacks released before the first landed, got %d", result.AttackCount)
+	}
+	if want := result.AttackCount - 1; result.WastedAttacks != want || result.WastedDamage <= 0 {
+		t.Errorf("Expected %d wasted attacks with damage, got %d and %.1f", want, result.WastedAttacks, result.WastedDamage)
+	}
+	if misses := eventsOfType(result.Events, EventProjectileMiss); len(misses) != result.WastedAttacks {
+		t.Errorf("Expected a miss event per wasted attack, got %d", len(misses))
+	}
+}
+
+func TestAttackManaGainedOnImpact(t *testing.T) {
+	unit := newTestUnit()
+	unit.Stats.SetBase(models.StatMana, 1000)
+	unit.AttackWindup = 0
+	unit.AttackRange = 4
+	unit.MissileSpeed = 4 // One second in flight
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 500 * time.Millisecond
+	result := simulator.Run()
+
+	if result.AttackCount != 1 || unit.CurrentMana != 0 || result.TotalDamage != 0 {
+		t.Errorf("Expected a released attack with no mana or damage before impact, got %d attacks, %.0f mana, %.0f damage",
+			result.AttackCount, unit.CurrentMana, result.TotalDamage)
+	}
+}
+
+func TestOverkillIsExcludedFromDPS(t *testing.T) {
+	unit := newTestUnit()
+	unit.Stats.SetBase(models.StatMana, 1000)
+
+	// Each attack deals 70 damage, far more than the small targets have
+	targets := []*models.Target{
+		models.NewTarget("Small", 10, 0, 0),
+		models.NewTarget("Medium", 100, 0, 0),
+	}
+	simulator := NewSimulator(unit, targets)
+	simulator.Config.Verbose = false
+	result := simulator.Run()
+
+	if result.TotalDamage != 110 {
+		t.Errorf("Expected effective damage equal to the targets' HP, got %.1f", result.TotalDamage)
+	}
+	if want := 60.0 + 40.0; result.OverkillDamage != want {
+		t.Errorf("Expected %.0f overkill, got %.1f", want, result.OverkillDamage)
+	}
+	if result.Overkill["Small"] != 60 || result.Overkill["Medium"] != 40 {
+		t.Errorf("Unexpected overkill by target %v", result.Overkill)
+	}
+	if result.RawDPS <= result.DPS || result.DPS != result.TotalDamage/simulator.Time.Seconds() {
+		t.Errorf("Expected DPS on effective damage below raw DPS, got %.1f and %.1f", result.DPS, result.RawDPS)
+	}
+
+	var logged float64
+	for _, event := range result.DamageLog {
+		logged += event.Damage
+	}
+	if logged != result.TotalDamage {
+		t.Errorf("Expected the damage log to hold effective damage, got %.1f", logged)
+	}
+}
+
+func TestCastersRegenManaWhileIdle(t *testing.T) {
+	unit := models.NewUnit(models.Unit{Name: "Caster", UnitRole: models.RoleMagicCaster, CurrentMana: 10}, models.Ability{Name: "None"},
+		map[models.StatType]float64{models.StatMana: 1000}, 2)
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 3500 * time.Millisecond
+	simulator.Config.Timeline = TimelineConfig{Interval: 100 * time.Millisecond, Mana: true}
+	unit.CurrentMana = 500 // Replaced by the starting mana
+	result := simulator.Run()
+
+	want := 10 + 3*2 + 7*float64(result.AttackCount)
+	if unit.CurrentMana != want {
+		t.Errorf("Expected 10 starting mana, 3 seconds of regen and 7 per attack, got %.0f want %.0f", unit.CurrentMana, want)
+	}
+	first, last := result.Timeline[0], result.Timeline[len(result.Timeline)-1]
+	if first.Mana != 10 || last.Mana != want || last.MaxMana != 1000 || last.ManaLocked {
+		t.Errorf("Expected mana sampled from 10 to %.0f of 1000, got %+v and %+v", want, first, last)
+	}
+}
+
+func TestManaLockedAfterCast(t *testing.T) {
+	unit := newTestUnit()
+	unit.Ability.ManaLock = time.Second
+	unit.Stats.SetBase(models.StatStartingMana, 30)
+	unit.Stats.SetBase(models.StatManaRegen, 10)
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 2500 * time.Millisecond
+	simulator.Config.Timeline = TimelineConfig{Interval: 100 * time.Millisecond, Mana: true}
+	result := simulator.Run()
+
+	// Cast at 0s until 0.5s and locked until 1.5s, so neither the attacks
+	// nor the regen at 1s grant mana until then
+	if len(result.CastWindows) != 1 {
+		t.Fatalf("Expected one cast, got %d", len(result.CastWindows))
+	}
+	for _, sample := range result.Timeline[1:] {
+		locked := sample.Timestamp < 1500*time.Millisecond
+		if sample.ManaLocked != locked {
+			t.Errorf("Expected mana lock %v at %v", locked, sample.Timestamp)
+		}
+		if locked && sample.Mana != 0 {
+			t.Errorf("Expected no mana gained while locked, got %.0f at %v", sample.Mana, sample.Timestamp)
+		}
+	}
+	if unit.CurrentMana < 10 {
+		t.Errorf("Expected mana gained after the lock, got %.0f", unit.CurrentMana)
+	}
+}
+
+func TestEmpoweredAutoRemovesItselfDuringAttack(t *testing.T) {
+	unit := newTestUnit()
+	unit.BuffManager.ApplyBuff(models.EmpoweredAutoBuff(10*time.Second, 500), 0)
+	unit.BuffManager.ApplyBuff(models.AttackSpeedBuff(10*time.Second, 0.2), 0)
+
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 3 * time.Second
+	result := simulator.Run()
+
+	if result.AttackCount == 0 {
+		t.Fatal("Expected the unit to attack")
+	}
+	if unit.BuffManager.HasBuff("Empowered Auto", simulator.Time) {
+		t.Error("Expected Empowered Auto to be used up by the first attack")
+	}
+	if !unit.BuffManager.HasBuff("Attack Speed Boost", simulator.Time) {
+		t.Error("Expected the other buff to stay active")
+	}
+}
@@ -0,0 +1,295 @@
+package sweep
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"tft-sim/sim/augments"
+	"tft-sim/sim/items"
+	"tft-sim/sim/mechanics"
+	"tft-sim/sim/traits"
+	"tft-sim/sim/units"
+	"time"
+)
+
+// Defaults used for grid dimensions left empty
+const (
+	DefaultStarLevel   = 2
+	DefaultArmor       = 100.0
+	DefaultMagicResist = 50.0
+	DefaultTargetHP    = 50000.0
+	DefaultDuration    = 30 * time.Second
+	DefaultSeed        = 1
+)
+
+// Grid lists the values to sweep for each parameter. Every combination is
+// simulated once per seed. Empty dimensions use a single default value.
+type Grid struct {
+	StarLevels  []int
+	Armor       []float64 // Target armor
+	MagicResist []float64 // Target magic resist
+	TargetHP    []float64
+	Durations   []time.Duration
+	ItemSets    [][]string
+	Seeds       []int64 // Crit RNG seeds, see sim.SimulationConfig.Seed
+}
+
+// Config describes the unit simulated at every grid point
+type Config struct {
+	Unit     string         // Registered unit name
+	Augments []string       // Augments applied at every point
+	Traits   map[string]int // Trait counts; nil counts the unit's own traits
+	Workers  int            // Zero uses one worker per CPU
+
+	// Bonuses are added to the unit's stats with AddBonus at every point,
+	// on top of its items
+	Bonuses map[models.StatType]float64
+
+	StartDelay time.Duration // See sim.SimulationConfig.StartDelay
+	PreCast    bool          // See sim.SimulationConfig.PreCast
+	Mechanics  []string      // Set mechanics in play, see sim.SetMechanic
+}
+
+// Point is one combination of grid values
+type Point struct {
+	StarLevel   int
+	Armor       float64
+	MagicResist float64
+	TargetHP    float64
+	Duration    time.Duration
+	Items       []string
+	ItemSet     int // Index of Items in Grid.ItemSets
+	Seed        int64
+}
+
+// Row is the outcome of simulating one point
+type Row struct {
+	Point
+	TotalDamage    float64 // Effective damage, see sim.SimulationResult.TotalDamage
+	DPS            float64
+	OverkillDamage float64
+	CritRate       float64
+	AttackCount    int
+	AbilityCount   int
+	TimeToKill     time.Duration // -1 if the target survived
+}
+
+// Table holds the rows of a sweep in grid order
+type Table struct {
+	Grid Grid // The grid with defaults filled in
+	Rows []Row
+}
+
+// withDefaults fills empty dimensions with their default value
+func (g Grid) withDefaults() Grid {
+	if len(g.StarLevels) == 0 {
+		g.StarLevels = []int{DefaultStarLevel}
+	}
+	if len(g.Armor) == 0 {
+		g.Armor = []float64{DefaultArmor}
+	}
+	if len(g.MagicResist) == 0 {
+		g.MagicResist = []float64{DefaultMagicResist}
+	}
+	if len(g.TargetHP) == 0 {
+		g.TargetHP = []float64{DefaultTargetHP}
+	}
+	if len(g.Durations) == 0 {
+		g.Durations = []time.Duration{DefaultDuration}
+	}
+	if len(g.ItemSets) == 0 {
+		g.ItemSets = [][]string{nil}
+	}
+	if len(g.Seeds) == 0 {
+		g.Seeds = []int64{DefaultSeed}
+	}
+	return g
+}
+
+// Points enumerates every combination of the grid. Seeds vary fastest and
+// star levels slowest.
+func (g Grid) Points() []Point {
+	g = g.withDefaults()
+
+	var points []Point
+	for _, star := range g.StarLevels {
+		for set, itemNames := range g.ItemSets {
+			for _, duration := range g.Durations {
+				for _, hp := range g.TargetHP {
+					for _, armor := range g.Armor {
+						for _, mr := range g.MagicResist {
+							for _, seed := range g.Seeds {
+								points = append(points, Point{
+									StarLevel:   star,
+									Armor:       armor,
+									MagicResist: mr,
+									TargetHP:    hp,
+									Duration:    duration,
+									Items:       itemNames,
+									ItemSet:     set,
+									Seed:        seed,
+								})
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return points
+}
+
+// ItemLabel names the point's item set for tables and charts
+func (p Point) ItemLabel() string {
+	if len(p.Items) == 0 {
+		return "No items"
+	}
+	return strings.Join(p.Items, ", ")
+}
+
+// Run simulates every point of the grid on a pool of workers and returns the
+// rows in grid order
+func Run(cfg Config, grid Grid) (Table, error) {
+	grid = grid.withDefaults()
+	if err := validate(cfg, grid); err != nil {
+		return Table{}, err
+	}
+
+	points := grid.Points()
+	rows := make([]Row, len(points))
+
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan int)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := range jobs {
+				if errs[w] != nil {
+					continue // Drain remaining jobs after a failure
+				}
+				rows[i], errs[w] = runPoint(cfg, points[i])
+			}
+		}(w)
+	}
+
+	for i := range points {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return Table{}, err
+		}
+	}
+
+	return Table{Grid: grid, Rows: rows}, nil
+}
+
+// validate checks names up front so workers only fail on invalid builds
+func validate(cfg Config, grid Grid) error {
+	for _, star := range grid.StarLevels {
+		if _, exists := units.Get(cfg.Unit, star); !exists {
+			return fmt.Errorf("unit %s not found in registry", cfg.Unit)
+		}
+	}
+	for _, itemNames := range grid.ItemSets {
+		for _, name := range itemNames {
+			if _, exists := items.Get(name); !exists {
+				return fmt.Errorf("item %s not found in registry", name)
+			}
+		}
+	}
+	for _, name := range cfg.Augments {
+		if _, exists := augments.Get(name); !exists {
+			return fmt.Errorf("augment %s not found in registry", name)
+		}
+	}
+	if _, err := mechanics.New(cfg.Mechanics); err != nil {
+		return err
+	}
+	return nil
+}
+
+// runPoint simulates a point and reduces the result to a row
+func runPoint(cfg Config, point Point) (Row, error) {
+	result, err := Simulate(cfg, point)
+	if err != nil {
+		return Row{}, err
+	}
+
+	return Row{
+		Point:          point,
+		TotalDamage:    result.TotalDamage,
+		DPS:            result.DPS,
+		OverkillDamage: result.OverkillDamage,
+		CritRate:       result.CritRate,
+		AttackCount:    result.AttackCount,
+		AbilityCount:   result.AbilityCount,
+		TimeToKill:     result.TimeToKill[TargetName],
+	}, nil
+}
+
+// TargetName is the name of the target Simulate fights
+const TargetName = "Target"
+
+// Simulate builds the unit for a single point and returns the full result,
+// for analyses that need more than a Row such as DamageOverTime
+func Simulate(cfg Config, point Point) (sim.SimulationResult, error) {
+	unit, exists := units.Get(cfg.Unit, point.StarLevel)
+	if !exists {
+		return sim.SimulationResult{}, fmt.Errorf("unit %s not found in registry", cfg.Unit)
+	}
+
+	for _, name := range point.Items {
+		item, exists := items.Get(name)
+		if !exists {
+			return sim.SimulationResult{}, fmt.Errorf("item %s not found in registry", name)
+		}
+		if err := unit.AddItem(item); err != nil {
+			return sim.SimulationResult{}, fmt.Errorf("invalid item set %s: %w", point.ItemLabel(), err)
+		}
+	}
+
+	for stat, value := range cfg.Bonuses {
+		unit.Stats.AddBonus(stat, value)
+	}
+
+	if err := augments.Apply(unit, cfg.Augments); err != nil {
+		return sim.SimulationResult{}, err
+	}
+
+	traitCounts := cfg.Traits
+	if traitCounts == nil {
+		traitCounts = traits.CountTraits([]*models.Unit{unit})
+	}
+	if err := traits.Apply(unit, traitCounts); err != nil {
+		return sim.SimulationResult{}, err
+	}
+
+	setMechanics, err := mechanics.New(cfg.Mechanics)
+	if err != nil {
+		return sim.SimulationResult{}, err
+	}
+
+	target := models.NewTarget(TargetName, point.TargetHP, point.Armor, point.MagicResist)
+	simulator := sim.NewSimulator(unit, []*models.Target{target})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = point.Duration
+	simulator.Config.Seed = point.Seed
+	simulator.Config.StartDelay = cfg.StartDelay
+	simulator.Config.PreCast = cfg.PreCast
+	simulator.Config.Mechanics = setMechanics
+	return simulator.Run(), nil
+}
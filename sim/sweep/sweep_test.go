@@ -0,0 +1,217 @@
+package sweep
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"tft-sim/models"
+	"time"
+)
+
+var testConfig = Config{Unit: "Yunara", Traits: map[string]int{}}
+
+func TestGridPoints(t *testing.T) {
+	grid := Grid{
+		Armor:    []float64{0, 100, 200},
+		ItemSets: [][]string{{"IE"}, {"Red"}},
+		Seeds:    []int64{1, 2},
+	}
+
+	points := grid.Points()
+	if len(points) != 12 {
+		t.Fatalf("Expected 12 points, got %d", len(points))
+	}
+
+	first := points[0]
+	if first.StarLevel != DefaultStarLevel || first.TargetHP != DefaultTargetHP || first.Duration != DefaultDuration {
+		t.Errorf("Expected defaults for unswept dimensions, got %+v", first)
+	}
+	if points[1].Seed != 2 || points[1].Armor != 0 {
+		t.Errorf("Expected seeds to vary fastest, got %+v", points[1])
+	}
+	if points[6].ItemSet != 1 || points[6].ItemLabel() != "Red" {
+		t.Errorf("Expected second half to use the second item set, got %+v", points[6])
+	}
+}
+
+func TestRunIsDeterministicAndOrdered(t *testing.T) {
+	grid := Grid{
+		Armor:     []float64{0, 200},
+		Durations: []time.Duration{5 * time.Second},
+		ItemSets:  [][]string{{"IE"}},
+		Seeds:     []int64{1, 2, 3},
+	}
+
+	serial, err := Run(Config{Unit: "Yunara", Workers: 1}, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := Run(Config{Unit: "Yunara", Workers: 4}, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(serial.Rows, parallel.Rows) {
+		t.Error("Expected the same rows regardless of worker count")
+	}
+	for i, point := range grid.Points() {
+		if !reflect.DeepEqual(serial.Rows[i].Point, point) {
+			t.Fatalf("Row %d is out of grid order", i)
+		}
+	}
+
+	// More armor means less damage
+	cells := serial.Aggregate(MetricDPS, DimArmor)
+	if len(cells) != 2 || cells[0].Count != 3 || cells[0].Mean <= cells[1].Mean {
+		t.Errorf("Expected DPS to fall with armor, got %+v", cells)
+	}
+}
+
+func TestRunRejectsUnknownNames(t *testing.T) {
+	if _, err := Run(Config{Unit: "Nobody"}, Grid{}); err == nil {
+		t.Error("Expected error for unknown unit")
+	}
+	if _, err := Run(testConfig, Grid{ItemSets: [][]string{{"Not An Item"}}}); err == nil {
+		t.Error("Expected error for unknown item")
+	}
+	if _, err := Run(testConfig, Grid{ItemSets: [][]string{{"IE", "IE"}}}); err == nil {
+		t.Error("Expected error for invalid item set")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	table := Table{Rows: []Row{
+		{Point: Point{Armor: 100, Seed: 1}, DPS: 10},
+		{Point: Point{Armor: 0, Seed: 1}, DPS: 30},
+		{Point: Point{Armor: 100, Seed: 2}, DPS: 20},
+	}}
+
+	cells := table.Aggregate(MetricDPS, DimArmor)
+	if len(cells) != 2 {
+		t.Fatalf("Expected 2 cells, got %d", len(cells))
+	}
+	if cells[0].Labels[0] != "0" || cells[0].Mean != 30 {
+		t.Errorf("Unexpected first cell %+v", cells[0])
+	}
+	if cells[1].Mean != 15 || cells[1].Min != 10 || cells[1].Max != 20 || cells[1].Count != 2 {
+		t.Errorf("Unexpected second cell %+v", cells[1])
+	}
+}
+
+func TestAggregateSkipsSurvivedTimeToKill(t *testing.T) {
+	table := Table{Rows: []Row{
+		{Point: Point{Armor: 0, Seed: 1}, TimeToKill: 4 * time.Second},
+		{Point: Point{Armor: 0, Seed: 2}, TimeToKill: -1},
+		{Point: Point{Armor: 0, Seed: 3}, TimeToKill: 6 * time.Second},
+		{Point: Point{Armor: 100, Seed: 1}, TimeToKill: -1},
+	}}
+
+	cells := table.Aggregate(MetricTimeToKill, DimArmor)
+	if len(cells) != 2 {
+		t.Fatalf("Expected 2 cells, got %d", len(cells))
+	}
+	if c := cells[0]; c.Mean != 5 || c.Min != 4 || c.Max != 6 || c.Count != 3 || c.Reached != 2 || c.Complete() {
+		t.Errorf("Expected the survived seed left out of the first cell, got %+v", c)
+	}
+	if c := cells[1]; !math.IsNaN(c.Mean) || !math.IsNaN(c.Min) || c.Count != 1 || c.Reached != 0 {
+		t.Errorf("Expected no time to kill for a cell that never killed, got %+v", c)
+	}
+
+	if c := table.Aggregate(MetricDPS, DimArmor)[0]; c.Reached != c.Count || !c.Complete() {
+		t.Errorf("Expected every row summarized for other metrics, got %+v", c)
+	}
+}
+
+func TestRunCountsAbilityCasts(t *testing.T) {
+	grid := Grid{Durations: []time.Duration{10 * time.Second}}
+
+	table, err := Run(testConfig, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row := table.Rows[0]
+	if row.AbilityCount == 0 || row.Metric(MetricAbilityCount) != float64(row.AbilityCount) {
+		t.Errorf("Expected ability casts over 10s, got %d", row.AbilityCount)
+	}
+
+	var buf bytes.Buffer
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := records[1][11]; got != strconv.Itoa(row.AbilityCount) {
+		t.Errorf("Expected ability_casts %d in the CSV, got %s", row.AbilityCount, got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	table := Table{Rows: []Row{
+		{Point: Point{StarLevel: 2, Items: []string{"IE", "Red"}, Duration: time.Second}, DPS: 12.5, TimeToKill: -1},
+	}}
+
+	var buf bytes.Buffer
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || len(records[1]) != len(csvHeader) {
+		t.Fatalf("Unexpected CSV shape: %v", records)
+	}
+	if records[1][5] != "IE, Red" || records[1][8] != "12.5" || records[1][12] != "-1" {
+		t.Errorf("Unexpected CSV row: %v", records[1])
+	}
+}
+
+func TestRunAppliesBonuses(t *testing.T) {
+	grid := Grid{Durations: []time.Duration{5 * time.Second}, ItemSets: [][]string{{"IE"}}}
+
+	base, err := Run(testConfig, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig
+	cfg.Bonuses = map[models.StatType]float64{models.StatAttackDamage: 0.5}
+	boosted, err := Run(cfg, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if boosted.Rows[0].DPS <= base.Rows[0].DPS {
+		t.Errorf("Expected bonus attack damage to raise DPS, got %.1f vs %.1f", boosted.Rows[0].DPS, base.Rows[0].DPS)
+	}
+}
+
+func TestRunAppliesMechanics(t *testing.T) {
+	grid := Grid{Durations: []time.Duration{5 * time.Second}, ItemSets: [][]string{{"IE"}}}
+
+	base, err := Run(testConfig, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig
+	cfg.Mechanics = []string{"Anomaly: Overdrive"}
+	boosted, err := Run(cfg, grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if boosted.Rows[0].DPS <= base.Rows[0].DPS {
+		t.Errorf("Expected the anomaly to raise DPS, got %.1f vs %.1f", boosted.Rows[0].DPS, base.Rows[0].DPS)
+	}
+
+	cfg.Mechanics = []string{"Missing"}
+	if _, err := Run(cfg, grid); err == nil {
+		t.Error("Expected an error for an unknown set mechanic")
+	}
+}
@@ -0,0 +1,250 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Dimension is a swept parameter
+type Dimension int
+
+const (
+	DimNone Dimension = iota
+	DimStarLevel
+	DimArmor
+	DimMagicResist
+	DimTargetHP
+	DimDuration
+	DimItems
+	DimSeed
+)
+
+var dimensionNames = map[Dimension]string{
+	DimNone:        "None",
+	DimStarLevel:   "Star Level",
+	DimArmor:       "Armor",
+	DimMagicResist: "Magic Resist",
+	DimTargetHP:    "Target HP",
+	DimDuration:    "Duration (s)",
+	DimItems:       "Items",
+	DimSeed:        "Seed",
+}
+
+func (d Dimension) String() string {
+	if name, ok := dimensionNames[d]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Categorical reports whether the dimension's values are labels rather
+// than quantities
+func (d Dimension) Categorical() bool {
+	return d == DimItems
+}
+
+// Value returns the point's value along a dimension. Item sets are
+// represented by their index in Grid.ItemSets.
+func (p Point) Value(d Dimension) float64 {
+	switch d {
+	case DimStarLevel:
+		return float64(p.StarLevel)
+	case DimArmor:
+		return p.Armor
+	case DimMagicResist:
+		return p.MagicResist
+	case DimTargetHP:
+		return p.TargetHP
+	case DimDuration:
+		return p.Duration.Seconds()
+	case DimItems:
+		return float64(p.ItemSet)
+	case DimSeed:
+		return float64(p.Seed)
+	}
+	return 0
+}
+
+// Label formats the point's value along a dimension
+func (p Point) Label(d Dimension) string {
+	if d == DimItems {
+		return p.ItemLabel()
+	}
+	return strconv.FormatFloat(p.Value(d), 'g', -1, 64)
+}
+
+// Metric is a simulation outcome recorded for each row
+type Metric int
+
+const (
+	MetricDPS Metric = iota
+	MetricTotalDamage
+	MetricCritRate
+	MetricAttackCount
+	MetricAbilityCount
+	MetricTimeToKill
+)
+
+var metricNames = map[Metric]string{
+	MetricDPS:          "DPS",
+	MetricTotalDamage:  "Total Damage",
+	MetricCritRate:     "Crit Rate",
+	MetricAttackCount:  "Attacks",
+	MetricAbilityCount: "Ability Casts",
+	MetricTimeToKill:   "Time to Kill (s)",
+}
+
+func (m Metric) String() string {
+	if name, ok := metricNames[m]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Metric returns the row's value for a metric. Time to kill is in seconds
+// and -1 when the target survived.
+func (r Row) Metric(m Metric) float64 {
+	switch m {
+	case MetricDPS:
+		return r.DPS
+	case MetricTotalDamage:
+		return r.TotalDamage
+	case MetricCritRate:
+		return r.CritRate
+	case MetricAttackCount:
+		return float64(r.AttackCount)
+	case MetricAbilityCount:
+		return float64(r.AbilityCount)
+	case MetricTimeToKill:
+		if r.TimeToKill < 0 {
+			return -1
+		}
+		return r.TimeToKill.Seconds()
+	}
+	return 0
+}
+
+// Cell summarizes a metric over the rows sharing the same values along
+// some dimensions. For time to kill, rows where the target survived are left
+// out of Mean, Min and Max, which are NaN when no row killed it.
+type Cell struct {
+	Values  []float64 // Value along each grouped dimension
+	Labels  []string  // Label along each grouped dimension
+	Mean    float64
+	Min     float64
+	Max     float64
+	Count   int // Rows in the group
+	Reached int // Rows summarized, fewer than Count when targets survived
+}
+
+// Complete reports whether every row of the cell was summarized. The mean
+// time to kill of an incomplete cell only covers the faster runs.
+func (c Cell) Complete() bool {
+	return c.Count > 0 && c.Reached == c.Count
+}
+
+// Aggregate groups rows by their values along dims and summarizes the metric
+// for each group, averaging over every other dimension (typically seeds).
+// Cells are sorted by their values in dims order.
+func (t Table) Aggregate(metric Metric, dims ...Dimension) []Cell {
+	index := make(map[string]int)
+	var cells []Cell
+
+	for _, row := range t.Rows {
+		values := make([]float64, len(dims))
+		labels := make([]string, len(dims))
+		for i, d := range dims {
+			values[i] = row.Value(d)
+			labels[i] = row.Label(d)
+		}
+		key := fmt.Sprint(values)
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(cells)
+			cells = append(cells, Cell{Values: values, Labels: labels})
+			i = len(cells) - 1
+		}
+
+		c := &cells[i]
+		c.Count++
+
+		// -1 marks a survived target, not a time
+		v := row.Metric(metric)
+		if metric == MetricTimeToKill && v < 0 {
+			continue
+		}
+		if c.Reached == 0 || v < c.Min {
+			c.Min = v
+		}
+		if c.Reached == 0 || v > c.Max {
+			c.Max = v
+		}
+		c.Mean += v
+		c.Reached++
+	}
+
+	for i := range cells {
+		c := &cells[i]
+		if c.Reached == 0 {
+			c.Mean, c.Min, c.Max = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		c.Mean /= float64(c.Reached)
+	}
+
+	sort.Slice(cells, func(a, b int) bool {
+		for i := range dims {
+			if cells[a].Values[i] != cells[b].Values[i] {
+				return cells[a].Values[i] < cells[b].Values[i]
+			}
+		}
+		return false
+	})
+	return cells
+}
+
+// csvHeader is the column order written by WriteCSV
+var csvHeader = []string{
+	"star_level", "armor", "magic_resist", "target_hp", "duration_s", "items", "seed",
+	"total_damage", "dps", "crit_rate", "attacks", "ability_casts", "time_to_kill_s",
+	"overkill_damage",
+}
+
+// WriteCSV writes one line per row with a header line
+func (t Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, row := range t.Rows {
+		record := []string{
+			strconv.Itoa(row.StarLevel),
+			f(row.Armor),
+			f(row.MagicResist),
+			f(row.TargetHP),
+			f(row.Duration.Seconds()),
+			row.ItemLabel(),
+			strconv.FormatInt(row.Seed, 10),
+			f(row.TotalDamage),
+			f(row.DPS),
+			f(row.CritRate),
+			strconv.Itoa(row.AttackCount),
+			strconv.Itoa(row.AbilityCount),
+			f(row.Metric(MetricTimeToKill)),
+			f(row.OverkillDamage),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,106 @@
+package sim
+
+import (
+	"tft-sim/models"
+	"time"
+)
+
+// TimelineConfig controls which values are sampled into SimulationResult.Timeline
+type TimelineConfig struct {
+	Interval   time.Duration     // Time between samples, zero disables recording
+	Stats      []models.StatType // Unit stats to sample
+	Mana       bool              // Sample current mana, max mana and mana lock
+	ItemStacks bool              // Sample ItemInstance.Stacks for every item
+	Buffs      bool              // Sample active buffs and their stack counts
+}
+
+// DefaultTimelineConfig samples the stats most useful for debugging item interactions
+func DefaultTimelineConfig() TimelineConfig {
+	return TimelineConfig{
+		Interval: 100 * time.Millisecond,
+		Stats: []models.StatType{
+			models.StatAttackSpeed,
+			models.StatAttackDamage,
+			models.StatAbilityPower,
+			models.StatDamageAmp,
+		},
+		Mana:       true,
+		ItemStacks: true,
+		Buffs:      true,
+	}
+}
+
+// StatSample is a snapshot of the unit's state at a point in the fight
+type StatSample struct {
+	Timestamp  time.Duration
+	Stats      map[models.StatType]float64
+	Mana       float64
+	MaxMana    float64
+	ManaLocked bool
+	ItemStacks map[string]int // Keyed by ItemInstance.UniqueName
+	Buffs      map[string]int // Active buff name to current stacks
+}
+
+// CastWindow is the span of time an ability cast was in progress
+type CastWindow struct {
+	Ability string
+	Start   time.Duration
+	End     time.Duration
+}
+
+// sampleTimeline records a StatSample if the next sample is due
+func (s *Simulator) sampleTimeline() {
+	cfg := s.Config.Timeline
+	if cfg.Interval <= 0 || s.Time < s.nextSample {
+		return
+	}
+	s.nextSample = s.Time + cfg.Interval
+
+	sample := StatSample{
+		Timestamp: s.Time,
+		Stats:     make(map[models.StatType]float64, len(cfg.Stats)),
+	}
+
+	for _, stat := range cfg.Stats {
+		sample.Stats[stat] = s.Unit.Stats.Get(stat)
+	}
+
+	if cfg.Mana {
+		sample.Mana = s.Unit.CurrentMana
+		sample.MaxMana = s.Unit.Stats.Get(models.StatMana)
+		sample.ManaLocked = s.Unit.IsManaLocked(s.Time)
+	}
+
+	if cfg.ItemStacks {
+		sample.ItemStacks = make(map[string]int, len(s.Unit.Items))
+		for _, item := range s.Unit.Items {
+			sample.ItemStacks[item.UniqueName] = item.Stacks
+		}
+	}
+
+	if cfg.Buffs && s.Unit.BuffManager != nil {
+		sample.Buffs = make(map[string]int)
+		s.Unit.BuffManager.ForEachActiveBuff(s.Time, func(buff *models.Buff) {
+			sample.Buffs[buff.Name] = buff.CurrentStacks
+		})
+	}
+
+	s.Results.Timeline = append(s.Results.Timeline, sample)
+}
+
+// openCastWindow starts tracking a cast for the timeline
+func (s *Simulator) openCastWindow() {
+	s.Results.CastWindows = append(s.Results.CastWindows, CastWindow{
+		Ability: s.Unit.Ability.Name,
+		Start:   s.Time,
+		End:     -1,
+	})
+}
+
+// closeCastWindow ends the most recent open cast window
+func (s *Simulator) closeCastWindow() {
+	n := len(s.Results.CastWindows)
+	if n > 0 && s.Results.CastWindows[n-1].End < 0 {
+		s.Results.CastWindows[n-1].End = s.Time
+	}
+}
@@ -0,0 +1,105 @@
+package sim
+
+import (
+	"testing"
+	"tft-sim/models"
+	"time"
+)
+
+func TestTimelineSamplesStacksAndBuffs(t *testing.T) {
+	item := models.Item{
+		Name: "Stacker",
+		OnAttackEffect: func(instance *models.ItemInstance) {
+			instance.Stacks++
+			buff := models.NewBuff("Frenzy", 10*time.Second).SetStacking(100, models.StackBehaviorAdditive)
+			instance.Owner.BuffManager.ApplyBuff(buff, instance.Owner.Stats.CurrentTime)
+		},
+	}
+
+	unit := newTestUnit()
+	if err := unit.AddItem(item); err != nil {
+		t.Fatal(err)
+	}
+	targets := []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)}
+
+	simulator := NewSimulator(unit, targets)
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 5 * time.Second
+	simulator.Config.Timeline = DefaultTimelineConfig()
+	result := simulator.Run()
+
+	samples := result.Timeline
+	if len(samples) < 40 {
+		t.Fatalf("Expected a sample every 100ms over 5s, got %d samples", len(samples))
+	}
+
+	// Samples land on the first tick at or after each interval
+	for i := 1; i < len(samples); i++ {
+		gap := samples[i].Timestamp - samples[i-1].Timestamp
+		if gap < 100*time.Millisecond || gap >= 100*time.Millisecond+simulator.Config.TickInterval {
+			t.Fatalf("Samples %d and %d are %v apart, want about 100ms", i-1, i, gap)
+		}
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	for _, stat := range DefaultTimelineConfig().Stats {
+		if _, ok := last.Stats[stat]; !ok {
+			t.Errorf("Sample is missing %v", stat)
+		}
+	}
+	if last.MaxMana != 30 {
+		t.Errorf("Expected max mana 30 in samples, got %v", last.MaxMana)
+	}
+
+	name := unit.Items[0].UniqueName
+	if first.ItemStacks[name] != 0 || last.ItemStacks[name] != result.AttackCount {
+		t.Errorf("Expected %s stacks to grow from 0 to %d, got %d to %d",
+			name, result.AttackCount, first.ItemStacks[name], last.ItemStacks[name])
+	}
+	if _, active := first.Buffs["Frenzy"]; active {
+		t.Error("Expected Frenzy to be inactive before the first attack")
+	}
+	if last.Buffs["Frenzy"] != result.AttackCount {
+		t.Errorf("Expected %d Frenzy stacks at the end, got %d", result.AttackCount, last.Buffs["Frenzy"])
+	}
+}
+
+func TestTimelineSamplesBuffStatsAtSampleTime(t *testing.T) {
+	item := models.Item{
+		Name: "Starter",
+		OnCombatStartEffect: func(instance *models.ItemInstance) {
+			buff := models.NewBuff("Opening", 500*time.Millisecond).AddStatBonus(models.StatAttackSpeed, 0.5)
+			instance.Owner.BuffManager.ApplyBuff(buff, 0)
+		},
+	}
+
+	unit := newTestUnit()
+	if err := unit.AddItem(item); err != nil {
+		t.Fatal(err)
+	}
+	simulator := NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = time.Second
+	simulator.Config.TickInterval = 100 * time.Millisecond
+	simulator.Config.Timeline = TimelineConfig{Interval: 100 * time.Millisecond, Stats: []models.StatType{models.StatAttackSpeed}, Buffs: true}
+	result := simulator.Run()
+
+	// The buff and its attack speed leave the samples together at 500ms
+	for _, sample := range result.Timeline {
+		_, active := sample.Buffs["Opening"]
+		boosted := sample.Stats[models.StatAttackSpeed] > 1.0
+		if want := sample.Timestamp < 500*time.Millisecond; active != want || boosted != want {
+			t.Errorf("At %v: buff active %v, attack speed boosted %v, want %v", sample.Timestamp, active, boosted, want)
+		}
+	}
+}
+
+func TestTimelineDisabledByDefault(t *testing.T) {
+	simulator := NewSimulator(newTestUnit(), []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	simulator.Config.Duration = 2 * time.Second
+
+	if result := simulator.Run(); len(result.Timeline) != 0 {
+		t.Errorf("Expected no samples without a timeline interval, got %d", len(result.Timeline))
+	}
+}
@@ -0,0 +1,27 @@
+package traits
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Trait{
+		Name:        "Duelist",
+		Description: "Duelists' attacks grant bonus Attack Speed, stacking up to 12 times. (2) 4% (4) 7% (6) 10%",
+		Breakpoints: []models.TraitBreakpoint{
+			{Count: 2, OnHitEffect: duelistOnHit(0.04)},
+			{Count: 4, OnHitEffect: duelistOnHit(0.07)},
+			{Count: 6, OnHitEffect: duelistOnHit(0.10)},
+		},
+	})
+}
+
+// duelistOnHit returns an on-hit effect that stacks attack speed
+func duelistOnHit(attackSpeed float64) func(*models.Unit, *models.Target, float64) {
+	return func(unit *models.Unit, target *models.Target, damage float64) {
+		buff := models.NewBuff("Duelist", 0)
+		buff.SetStacking(12, models.StackBehaviorAdditive)
+		buff.AddStatBonus(models.StatAttackSpeed, attackSpeed)
+		unit.BuffManager.ApplyBuff(buff, unit.Stats.CurrentTime)
+	}
+}
@@ -0,0 +1,17 @@
+package traits
+
+import (
+	"tft-sim/models"
+)
+
+func init() {
+	Register(models.Trait{
+		Name:        "Marksman",
+		Description: "Marksmen gain bonus Attack Damage. (2) 15% (4) 35% (6) 60%",
+		Breakpoints: []models.TraitBreakpoint{
+			{Count: 2, Stats: map[models.StatType]float64{models.StatAttackDamage: 0.15}},
+			{Count: 4, Stats: map[models.StatType]float64{models.StatAttackDamage: 0.35}},
+			{Count: 6, Stats: map[models.StatType]float64{models.StatAttackDamage: 0.60}},
+		},
+	})
+}
@@ -0,0 +1,81 @@
+package traits
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"tft-sim/models"
+)
+
+var (
+	registry = make(map[string]models.Trait)
+	mu       sync.RWMutex
+)
+
+func Register(trait models.Trait) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[trait.Name] = trait
+}
+
+func Get(name string) (models.Trait, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	trait, exists := registry[name]
+	return trait, exists
+}
+
+func GetAll() map[string]models.Trait {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	// Return a copy
+	copy := make(map[string]models.Trait)
+	for k, v := range registry {
+		copy[k] = v
+	}
+	return copy
+}
+
+// CountTraits computes trait counts for a board. Like in game, each distinct
+// champion only counts once towards a trait.
+func CountTraits(board []*models.Unit) map[string]int {
+	counts := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, unit := range board {
+		if seen[unit.Name] {
+			continue
+		}
+		seen[unit.Name] = true
+		for _, trait := range unit.Traits {
+			counts[trait]++
+		}
+	}
+	return counts
+}
+
+// Apply activates every trait in counts that reaches a breakpoint and applies
+// to the unit. Non team-wide traits only apply if the unit declares them.
+func Apply(unit *models.Unit, counts map[string]int) error {
+	// Apply in name order so stat application is deterministic
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		trait, exists := Get(name)
+		if !exists {
+			return fmt.Errorf("trait %s not found in registry", name)
+		}
+
+		if !trait.TeamWide && !unit.HasTrait(name) {
+			continue
+		}
+
+		unit.AddTrait(trait, counts[name])
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package traits
+
+import (
+	"math"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+)
+
+func newTraitUnit(name string, traitNames ...string) *models.Unit {
+	baseStats := map[models.StatType]float64{
+		models.StatHealth:       1000,
+		models.StatAttackDamage: 100,
+		models.StatAttackSpeed:  1.0,
+		models.StatMana:         1000,
+	}
+	template := models.Unit{Name: name, UnitRole: models.RoleAttackMarksman, StarLevel: 1, Traits: traitNames}
+	return models.NewUnit(template, models.Ability{Name: "None"}, baseStats, 2)
+}
+
+func TestBreakpointSelection(t *testing.T) {
+	trait, exists := Get("Marksman")
+	if !exists {
+		t.Fatal("Marksman trait not found in registry")
+	}
+
+	cases := map[int]int{1: 0, 2: 2, 3: 2, 4: 4, 5: 4, 6: 6, 9: 6}
+	for count, want := range cases {
+		bp, ok := trait.Breakpoint(count)
+		if want == 0 {
+			if ok {
+				t.Errorf("Breakpoint(%d) should not be active", count)
+			}
+			continue
+		}
+		if !ok || bp.Count != want {
+			t.Errorf("Breakpoint(%d) = %d, want %d", count, bp.Count, want)
+		}
+	}
+}
+
+func TestCountTraitsCountsUniqueUnits(t *testing.T) {
+	board := []*models.Unit{
+		newTraitUnit("A", "Marksman", "Duelist"),
+		newTraitUnit("A", "Marksman", "Duelist"),
+		newTraitUnit("B", "Marksman"),
+	}
+
+	counts := CountTraits(board)
+	if counts["Marksman"] != 2 {
+		t.Errorf("Expected 2 Marksman, got %d", counts["Marksman"])
+	}
+	if counts["Duelist"] != 1 {
+		t.Errorf("Expected 1 Duelist, got %d", counts["Duelist"])
+	}
+}
+
+func TestApplyOnlyAffectsUnitsWithTrait(t *testing.T) {
+	marksman := newTraitUnit("A", "Marksman")
+	other := newTraitUnit("B")
+
+	counts := map[string]int{"Marksman": 4}
+	if err := Apply(marksman, counts); err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(other, counts); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := marksman.Stats.Get(models.StatAttackDamage); math.Abs(got-135) > 1e-9 {
+		t.Errorf("Expected Marksman (4) to give 135 AD, got %.2f", got)
+	}
+	if len(marksman.ActiveTraits) != 1 || marksman.ActiveTraits[0].String() != "Marksman (4)" {
+		t.Errorf("Unexpected active traits: %v", marksman.ActiveTraits)
+	}
+
+	if got := other.Stats.Get(models.StatAttackDamage); got != 100 {
+		t.Errorf("Expected unit without the trait to keep 100 AD, got %.2f", got)
+	}
+	if len(other.ActiveTraits) != 0 {
+		t.Errorf("Expected no active traits, got %v", other.ActiveTraits)
+	}
+
+	if err := Apply(marksman, map[string]int{"Unknown": 2}); err == nil {
+		t.Error("Expected error for unknown trait")
+	}
+}
+
+func TestTraitEffectsDispatched(t *testing.T) {
+	var seconds, hits int
+
+	// Added to the unit directly so the trait stays out of the global registry
+	trait := models.Trait{
+		Name:     "Test Trait",
+		TeamWide: true,
+		Breakpoints: []models.TraitBreakpoint{
+			{
+				Count:          1,
+				Buffs:          []func() *models.Buff{func() *models.Buff { return models.NewBuff("Test Trait Buff", 0) }},
+				OnSecondEffect: func(*models.Unit) { seconds++ },
+				OnHitEffect:    func(*models.Unit, *models.Target, float64) { hits++ },
+			},
+		},
+	}
+
+	unit := newTraitUnit("A")
+	unit.AddTrait(trait, 1)
+
+	simulator := sim.NewSimulator(unit, []*models.Target{models.NewTarget("Dummy", 50000, 0, 0)})
+	simulator.Config.Verbose = false
+	results := simulator.Run()
+
+	if seconds == 0 {
+		t.Error("Expected per-second trait effect to fire")
+	}
+	if hits != results.AttackCount {
+		t.Errorf("Expected one on-hit trait effect per attack, got %d hits for %d attacks", hits, results.AttackCount)
+	}
+	if !unit.BuffManager.HasBuff("Test Trait Buff", simulator.Time) {
+		t.Error("Expected combat start trait buff to be applied")
+	}
+	if len(results.ActiveTraits) != 1 || results.ActiveTraits[0] != "Test Trait (1)" {
+		t.Errorf("Unexpected result traits: %v", results.ActiveTraits)
+	}
+}
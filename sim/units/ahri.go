@@ -0,0 +1,38 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Ahri", NewAhri)
+}
+
+var ahriStats = starStats{
+	Health:       [3]float64{650, 1170, 2106},
+	AttackDamage: [3]float64{40, 60, 90},
+	AttackSpeed:  0.75,
+	Armor:        20,
+	MagicResist:  20,
+	Mana:         50,
+}
+
+// NewAhri creates a caster whose orb deals magic damage to every enemy
+func NewAhri(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Spirit Orb",
+		CastTime: 500 * time.Millisecond,
+		Script:   "damage [180/270/420]% AP magic to all",
+	})
+
+	template := models.Unit{
+		Name:         "Ahri",
+		UnitRole:     models.RoleMagicCaster,
+		StarLevel:    starLevel,
+		CurrentMana:  10,
+		MissileSpeed: 20,
+	}
+	return models.NewUnit(template, ability, ahriStats.at(starLevel), 2)
+}
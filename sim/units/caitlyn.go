@@ -0,0 +1,41 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Caitlyn", NewCaitlyn)
+}
+
+var caitlynStats = starStats{
+	Health:       [3]float64{650, 1170, 2106},
+	AttackDamage: [3]float64{60, 90, 135},
+	AttackSpeed:  0.7,
+	Armor:        20,
+	MagicResist:  20,
+	Mana:         80,
+}
+
+// NewCaitlyn creates a long range marksman who snipes the healthiest enemy
+// with a single-target nuke
+func NewCaitlyn(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Ace in the Hole",
+		CastTime: time.Second,
+		Script:   "damage [450/675/1100]% AD physical to highest",
+	})
+
+	template := models.Unit{
+		Name:         "Caitlyn",
+		UnitRole:     models.RoleAttackMarksman,
+		StarLevel:    starLevel,
+		Traits:       []string{"Marksman"},
+		CurrentMana:  20,
+		AttackRange:  6,
+		MissileSpeed: 30,
+	}
+	return models.NewUnit(template, ability, caitlynStats.at(starLevel), 2)
+}
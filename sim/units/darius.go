@@ -0,0 +1,42 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Darius", NewDarius)
+}
+
+var dariusStats = starStats{
+	Health:       [3]float64{900, 1620, 2916},
+	AttackDamage: [3]float64{65, 98, 146},
+	AttackSpeed:  0.7,
+	Armor:        45,
+	MagicResist:  45,
+	Mana:         70,
+}
+
+// NewDarius creates a fighter who swings his axe at every adjacent enemy and
+// heals himself
+func NewDarius(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Decimate",
+		CastTime: 750 * time.Millisecond,
+		Script: `
+			damage [275/410/650]% AD physical to all
+			heal [200/250/300]% AP
+		`,
+	})
+
+	template := models.Unit{
+		Name:        "Darius",
+		UnitRole:    models.RoleAttackFighter,
+		StarLevel:   starLevel,
+		Traits:      []string{"Duelist"},
+		CurrentMana: 20,
+	}
+	return models.NewUnit(template, ability, dariusStats.at(starLevel), 2)
+}
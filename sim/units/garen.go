@@ -0,0 +1,40 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Garen", NewGaren)
+}
+
+var garenStats = starStats{
+	Health:       [3]float64{1000, 1800, 3240},
+	AttackDamage: [3]float64{60, 90, 135},
+	AttackSpeed:  0.7,
+	Armor:        50,
+	MagicResist:  50,
+	Mana:         80,
+}
+
+// NewGaren creates a fighter who channels a spin, damaging every enemy in
+// waves
+func NewGaren(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Judgment",
+		CastTime: 3 * time.Second,
+		Waves:    6,
+		Script:   "damage [60/90/140]% AD physical to all",
+	})
+
+	template := models.Unit{
+		Name:        "Garen",
+		UnitRole:    models.RoleAttackFighter,
+		StarLevel:   starLevel,
+		Traits:      []string{"Duelist"},
+		CurrentMana: 20,
+	}
+	return models.NewUnit(template, ability, garenStats.at(starLevel), 2)
+}
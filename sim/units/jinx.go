@@ -0,0 +1,43 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Jinx", NewJinx)
+}
+
+var jinxStats = starStats{
+	Health:       [3]float64{700, 1260, 2268},
+	AttackDamage: [3]float64{55, 83, 124},
+	AttackSpeed:  0.75,
+	Armor:        25,
+	MagicResist:  25,
+	Mana:         60,
+}
+
+// NewJinx creates a marksman whose rocket hits every enemy before she gets
+// excited and attacks much faster for a few seconds
+func NewJinx(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Get Excited!",
+		CastTime: 500 * time.Millisecond,
+		Script: `
+			damage [120/180/280]% AD physical to all
+			buff "Get Excited!" for 5s with +[60/70/90]% AS
+		`,
+	})
+
+	template := models.Unit{
+		Name:         "Jinx",
+		UnitRole:     models.RoleAttackMarksman,
+		StarLevel:    starLevel,
+		Traits:       []string{"Marksman"},
+		CurrentMana:  10,
+		MissileSpeed: 25,
+	}
+	return models.NewUnit(template, ability, jinxStats.at(starLevel), 2)
+}
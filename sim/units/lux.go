@@ -0,0 +1,41 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Lux", NewLux)
+}
+
+var luxStats = starStats{
+	Health:       [3]float64{600, 1080, 1944},
+	AttackDamage: [3]float64{40, 60, 90},
+	AttackSpeed:  0.7,
+	Armor:        20,
+	MagicResist:  20,
+	Mana:         60,
+}
+
+// NewLux creates a caster who nukes and stuns her target
+func NewLux(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Final Spark",
+		CastTime: 750 * time.Millisecond,
+		Script: `
+			damage [320/480/800]% AP magic to target
+			stun target for [1.5/1.75/2]s
+		`,
+	})
+
+	template := models.Unit{
+		Name:         "Lux",
+		UnitRole:     models.RoleMagicCaster,
+		StarLevel:    starLevel,
+		CurrentMana:  20,
+		MissileSpeed: 20,
+	}
+	return models.NewUnit(template, ability, luxStats.at(starLevel), 2)
+}
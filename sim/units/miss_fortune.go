@@ -0,0 +1,41 @@
+package units
+
+import (
+	"tft-sim/models"
+	"tft-sim/sim/abilities"
+	"time"
+)
+
+func init() {
+	Register("Miss Fortune", NewMissFortune)
+}
+
+var missFortuneStats = starStats{
+	Health:       [3]float64{750, 1350, 2430},
+	AttackDamage: [3]float64{55, 83, 124},
+	AttackSpeed:  0.75,
+	Armor:        25,
+	MagicResist:  25,
+	Mana:         100,
+}
+
+// NewMissFortune creates a marksman who channels waves of bullets at every
+// enemy, unable to attack until the channel ends
+func NewMissFortune(starLevel int) *models.Unit {
+	ability := abilities.MustCompile(abilities.Spec{
+		Name:     "Bullet Time",
+		CastTime: 2 * time.Second,
+		Waves:    8,
+		Script:   "damage [45/68/105]% AD + [10/15/25]% AP physical to all",
+	})
+
+	template := models.Unit{
+		Name:         "Miss Fortune",
+		UnitRole:     models.RoleAttackMarksman,
+		StarLevel:    starLevel,
+		Traits:       []string{"Marksman"},
+		CurrentMana:  30,
+		MissileSpeed: 25,
+	}
+	return models.NewUnit(template, ability, missFortuneStats.at(starLevel), 2)
+}
@@ -0,0 +1,102 @@
+package units
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"tft-sim/models"
+	"tft-sim/sim"
+	"time"
+)
+
+func TestRosterBaseStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   models.Role
+		traits []string
+		stats  starStats
+	}{
+		{"Jinx", models.RoleAttackMarksman, []string{"Marksman"}, jinxStats},
+		{"Caitlyn", models.RoleAttackMarksman, []string{"Marksman"}, caitlynStats},
+		{"Miss Fortune", models.RoleAttackMarksman, []string{"Marksman"}, missFortuneStats},
+		{"Lux", models.RoleMagicCaster, nil, luxStats},
+		{"Ahri", models.RoleMagicCaster, nil, ahriStats},
+		{"Darius", models.RoleAttackFighter, []string{"Duelist"}, dariusStats},
+		{"Garen", models.RoleAttackFighter, []string{"Duelist"}, garenStats},
+	}
+
+	for _, tt := range tests {
+		for star := 1; star <= 3; star++ {
+			unit, ok := Get(tt.name, star)
+			if !ok {
+				t.Fatalf("%s is not registered", tt.name)
+			}
+
+			if unit.StarLevel != star || unit.UnitRole != tt.role {
+				t.Errorf("%s %d*: got star %d role %v, want role %v", tt.name, star, unit.StarLevel, unit.UnitRole, tt.role)
+			}
+			if !reflect.DeepEqual(unit.Traits, tt.traits) {
+				t.Errorf("%s %d*: got traits %v, want %v", tt.name, star, unit.Traits, tt.traits)
+			}
+
+			want := map[models.StatType]float64{
+				models.StatHealth:       tt.stats.Health[star-1],
+				models.StatAttackDamage: tt.stats.AttackDamage[star-1],
+				models.StatAttackSpeed:  tt.stats.AttackSpeed,
+				models.StatArmor:        tt.stats.Armor,
+				models.StatMagicResist:  tt.stats.MagicResist,
+				models.StatMana:         tt.stats.Mana,
+			}
+			for stat, value := range want {
+				if got := unit.Stats.Get(stat); got != value {
+					t.Errorf("%s %d*: stat %v = %v, want %v", tt.name, star, stat, got, value)
+				}
+			}
+			if unit.Ability.OnCast == nil {
+				t.Errorf("%s %d*: ability %q has no effect", tt.name, star, unit.Ability.Name)
+			}
+		}
+	}
+}
+
+func TestRosterGoldenDamage(t *testing.T) {
+	// Captured from a seeded 15s run against three 5000 HP targets with 50
+	// armor and MR. Update deliberately when a unit or the simulator changes.
+	tests := []struct {
+		name   string
+		damage float64
+	}{
+		{"Jinx", 1593.60},
+		{"Caitlyn", 1137.00},
+		{"Miss Fortune", 1818.11},
+		{"Lux", 808.00},
+		{"Ahri", 1568.00},
+		{"Darius", 1600.67},
+		{"Garen", 1644.00},
+	}
+
+	for _, tt := range tests {
+		unit, ok := Get(tt.name, 2)
+		if !ok {
+			t.Fatalf("%s is not registered", tt.name)
+		}
+		targets := []*models.Target{
+			models.NewTarget("Target 1", 5000, 50, 50),
+			models.NewTarget("Target 2", 5000, 50, 50),
+			models.NewTarget("Target 3", 5000, 50, 50),
+		}
+
+		simulator := sim.NewSimulator(unit, targets)
+		simulator.Config.Verbose = false
+		simulator.Config.Duration = 15 * time.Second
+		simulator.Config.Seed = 42
+		result := simulator.Run()
+
+		if math.Abs(result.TotalDamage-tt.damage) > 0.01 {
+			t.Errorf("%s: got %.2f damage, want %.2f", tt.name, result.TotalDamage, tt.damage)
+		}
+		if rolls := unit.CritTracker.TotalAttacks; rolls != result.AttackCount {
+			t.Errorf("%s: got %d crit rolls for %d attacks", tt.name, rolls, result.AttackCount)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package units
+
+import "tft-sim/models"
+
+// starStats holds a champion's base stats at one, two and three stars.
+// Health and attack damage grow with star level, the rest stay the same.
+type starStats struct {
+	Health       [3]float64
+	AttackDamage [3]float64
+	AttackSpeed  float64
+	Armor        float64
+	MagicResist  float64
+	Mana         float64
+}
+
+// at returns the base stats for a star level, clamped to one to three stars
+func (s starStats) at(starLevel int) map[models.StatType]float64 {
+	i := starLevel - 1
+	if i < 0 {
+		i = 0
+	}
+	if i > 2 {
+		i = 2
+	}
+	return map[models.StatType]float64{
+		models.StatHealth:       s.Health[i],
+		models.StatAttackDamage: s.AttackDamage[i],
+		models.StatAttackSpeed:  s.AttackSpeed,
+		models.StatArmor:        s.Armor,
+		models.StatMagicResist:  s.MagicResist,
+		models.StatMana:         s.Mana,
+		models.StatCritChance:   0.25,
+		models.StatCritDamage:   0.4,
+	}
+}
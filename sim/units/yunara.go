@@ -3,6 +3,7 @@ package units
 import (
 	"fmt"
 	"tft-sim/models"
+	"tft-sim/sim/abilities"
 	"time"
 )
 
@@ -36,9 +37,12 @@ func NewYunara(starLevel int) *models.Unit {
 		Name:         "Yunara",
 		UnitRole:     models.RoleAttackMarksman,
 		StarLevel:    starLevel,
+		Traits:       []string{"Marksman"},
 		CurrentMana:  0,
 		AttackTimer:  0,
 		AttackWindup: 20 * time.Millisecond,
+		AttackRange:  4,
+		MissileSpeed: 25, // Reaches a target at full range in 160ms
 	}
 
 	// Stage is hardcoded to 2 for now (as in main.go)
@@ -48,51 +52,37 @@ func NewYunara(starLevel int) *models.Unit {
 	return unit
 }
 
-// createTranscendentStateAbility creates Yunara's Transcendent State ability
+// Transcendent State values by star level
+var (
+	transcendentLaserDamage = abilities.Value{85, 130, 450}
+	transcendentAttackSpeed = abilities.Value{0.75, 0.75, 3.0} // Scales with AP
+)
+
+// createTranscendentStateAbility creates Yunara's Transcendent State ability.
+// Its buff scales attack speed with AP and replaces her attacks with lasers,
+// which the script language cannot express, so it is applied from Go when
+// the cast starts.
 func createTranscendentStateAbility(starLevel int) models.Ability {
-	// Determine values based on star level
-	var baseDamage float64
-	var damageReductionPerTarget float64
-	var attackSpeedBonus float64
-
-	switch starLevel {
-	case 1:
-		baseDamage = 85
-		damageReductionPerTarget = 0.7 // 70%
-		attackSpeedBonus = 0.75        // 75%
-	case 2:
-		baseDamage = 130
-		damageReductionPerTarget = 0.7 // 70%
-		attackSpeedBonus = 0.75        // 75%
-	case 3:
-		baseDamage = 450
-		damageReductionPerTarget = 0.3 // 30%
-		attackSpeedBonus = 3.0         // 300%
-	default:
-		// Default to 1-star values
-		baseDamage = 85
-		damageReductionPerTarget = 0.7
-		attackSpeedBonus = 0.75
-	}
+	baseDamage := transcendentLaserDamage.At(starLevel)
+	attackSpeedBonus := transcendentAttackSpeed.At(starLevel)
 
-	return models.Ability{
+	ability := abilities.MustCompile(abilities.Spec{
 		Name:                        "Transcendent State",
-		BaseDamage:                  baseDamage,
-		DamageType:                  models.DamageTypePhysical,
 		CastTime:                    4 * time.Second,
+		AllowsAutoAttacksDuringCast: true,
 		IsAoE:                       true,
 		IsAutoAttackModifier:        true,
-		AllowsManaGainDuringCast:    false,
-		AllowsAutoAttacksDuringCast: true,
 		OnCastStart: func(u *models.Unit) {
-			// Apply Transcendent State buff
-			applyTranscendentStateBuff(u, starLevel, baseDamage, damageReductionPerTarget, attackSpeedBonus)
+			applyTranscendentStateBuff(u, starLevel, baseDamage, attackSpeedBonus)
 		},
-	}
+	})
+	ability.BaseDamage = baseDamage
+	ability.DamageType = models.DamageTypePhysical
+	return ability
 }
 
 // applyTranscendentStateBuff applies the Transcendent State buff to the unit
-func applyTranscendentStateBuff(u *models.Unit, starLevel int, baseDamage, damageReduction, attackSpeedBonus float64) {
+func applyTranscendentStateBuff(u *models.Unit, starLevel int, baseDamage, attackSpeedBonus float64) {
 	// Calculate actual attack speed bonus (AP scaling)
 	ap := u.Stats.Get(models.StatAbilityPower)
 	actualAttackSpeedBonus := attackSpeedBonus + (ap * attackSpeedBonus)
@@ -100,22 +90,24 @@ func applyTranscendentStateBuff(u *models.Unit, starLevel int, baseDamage, damag
 	// Create the buff
 	buff := models.NewBuff("Transcendent State", 4*time.Second).
 		AddStatBonus(models.StatAttackSpeed, actualAttackSpeedBonus).
-		SetAutoAttackOverride(createLaserAttackOverride(u, starLevel, baseDamage, damageReduction)).
+		SetAutoAttackOverride(createLaserAttackOverride(u, starLevel, baseDamage)).
 		SetCallbacks(
 			func(u *models.Unit) {
-				fmt.Printf("[Buff Applied] %s enters Transcendent State (+%.0f%% attack speed)\n",
-					u.Name, actualAttackSpeedBonus*100)
+				if u.Verbose {
+					fmt.Printf("[Buff Applied] %s enters Transcendent State (+%.0f%% attack speed)\n",
+						u.Name, actualAttackSpeedBonus*100)
+				}
 			},
 			nil,
 			func(u *models.Unit) {
-				fmt.Printf("[Buff Expired] %s leaves Transcendent State\n", u.Name)
-				if u.CastingCtx != nil {
+				if u.Verbose {
+					fmt.Printf("[Buff Expired] %s leaves Transcendent State\n", u.Name)
 				}
 			},
 			nil,
 			func(u *models.Unit, t *models.Target, f float64, b bool) (float64, models.DamageType, bool) {
 				if b {
-					t.TakeDamage(f*0.3, models.DamageTypeTrue)
+					// The simulator applies the returned bonus damage
 					return f * 0.3, models.DamageTypeTrue, b
 				}
 				return 0, models.DamageTypeTrue, b
@@ -127,7 +119,7 @@ func applyTranscendentStateBuff(u *models.Unit, starLevel int, baseDamage, damag
 }
 
 // createLaserAttackOverride creates a function that overrides auto-attacks with lasers
-func createLaserAttackOverride(u *models.Unit, starLevel int, baseDamage, damageReduction float64) func(*models.Unit, *models.Target) float64 {
+func createLaserAttackOverride(u *models.Unit, starLevel int, baseDamage float64) func(*models.Unit, *models.Target) float64 {
 	return func(attacker *models.Unit, target *models.Target) float64 {
 		// Assume only one target
 